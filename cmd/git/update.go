@@ -2,7 +2,6 @@ package git
 
 import (
 	"fmt"
-	"path/filepath"
 	"slices"
 	"strings"
 
@@ -15,12 +14,13 @@ import (
 
 // ProjectInfo contiene le informazioni di stato di un progetto Git
 type ProjectInfo struct {
-	Name            string // Nome del progetto
-	Path            string // Percorso assoluto del progetto
-	CurrentBranch   string // Nome del branch corrente
-	IsDevelop       bool   // true se il branch corrente è 'develop'
-	IsDeploy        bool   // true se il branch corrente inizia con 'deploy/'
-	switchToDevelop bool   // true se l'utente vuole passare a 'develop'
+	Name                string                 // Nome del progetto
+	Path                string                 // Percorso assoluto del progetto
+	CurrentBranch       string                 // Nome del branch corrente
+	Policy              config.BranchingPolicy // Modello di branch del repository
+	IsIntegration       bool                   // true se il branch corrente è quello di integrazione (es. 'develop')
+	IsRelease           bool                   // true se il branch corrente è un branch di rilascio (es. 'deploy/*')
+	switchToIntegration bool                   // true se l'utente vuole passare al branch di integrazione
 }
 
 // updateCmd rappresenta il comando per aggiornare i progetti con git pull/merge
@@ -31,10 +31,11 @@ var updateCmd = &cobra.Command{
 Questo comando mantiene aggiornati tutti i progetti scaricando le ultime modifiche
 dai rispettivi repository remoti.
 
-Il comportamento varia in base al branch corrente:
-  - Branch 'develop': esegue git pull da origin/develop
-  - Branch 'deploy/*': esegue git pull dal branch corrente
-  - Altri branch: esegue git fetch + git merge di origin/develop
+Il comportamento varia in base al branch corrente e al modello di branch del repository
+(git_branching nel profilo o nelle impostazioni del progetto):
+  - Branch di integrazione (default 'develop'): esegue git pull dal remote (default 'origin')
+  - Branch di rilascio (default 'deploy/*'): esegue git pull dal branch corrente
+  - Altri branch: esegue git fetch + git merge del branch di integrazione remoto
 
 Prima delle operazioni, eventuali modifiche non committate vengono salvate in stash
 e automaticamente ripristinate al termine.`,
@@ -51,7 +52,7 @@ e automaticamente ripristinate al termine.`,
 			return
 		}
 
-		// Gestisce la selezione interattiva dei progetti da passare a develop
+		// Gestisce la selezione interattiva dei progetti da passare al branch di integrazione
 		if err := handleBranchSwitching(projectInfos); err != nil {
 			return
 		}
@@ -68,86 +69,87 @@ func gatherProjectsInfo(cfg *config.Config) ([]ProjectInfo, error) {
 	projectInfos := make([]ProjectInfo, 0, len(cfg.SelectedProjects))
 
 	for _, projectName := range cfg.SelectedProjects {
-		path := filepath.Join(cfg.RootOfProjects, projectName)
-		isDevelop, isDeploy, currBranch, err := branchInformation(path)
+		path := cfg.ProjectDir(projectName)
+		policy := cfg.BranchingPolicy(projectName)
+		isIntegration, isRelease, currBranch, err := branchInformation(path, policy)
 		if err != nil {
 			pterm.Error.Printf("Errore nel recuperare le informazioni del branch per '%s': %v\n", projectName, err)
 			return nil, err
 		}
 
 		projectInfos = append(projectInfos, ProjectInfo{
-			Name:            projectName,
-			Path:            path,
-			IsDevelop:       isDevelop,
-			IsDeploy:        isDeploy,
-			CurrentBranch:   currBranch,
-			switchToDevelop: !isDevelop, // Default: suggerisci di passare a develop se non ci sei già
+			Name:                projectName,
+			Path:                path,
+			Policy:              policy,
+			IsIntegration:       isIntegration,
+			IsRelease:           isRelease,
+			CurrentBranch:       currBranch,
+			switchToIntegration: !isIntegration, // Default: suggerisci di passare al branch di integrazione se non ci sei già
 		})
 	}
 
 	return projectInfos, nil
 }
 
-// handleBranchSwitching gestisce la selezione interattiva dei progetti da passare a develop
+// handleBranchSwitching gestisce la selezione interattiva dei progetti da passare al branch di integrazione
 func handleBranchSwitching(projectInfos []ProjectInfo) error {
-	// Filtra i progetti che non sono su develop
-	nonDevelopProjects := filterNonDevelopProjects(projectInfos)
+	// Filtra i progetti che non sono sul branch di integrazione
+	nonIntegrationProjects := filterNonIntegrationProjects(projectInfos)
 
-	if len(nonDevelopProjects) == 0 {
-		pterm.Info.Println("Tutti i progetti sono già sul branch 'develop'")
+	if len(nonIntegrationProjects) == 0 {
+		pterm.Info.Println("Tutti i progetti sono già sul branch di integrazione")
 		return nil
 	}
 
-	// Mostra i progetti non su develop e permette la selezione
-	pterm.Info.Println("Alcuni progetti non sono attualmente sul branch 'develop'")
+	// Mostra i progetti non sul branch di integrazione e permette la selezione
+	pterm.Info.Println("Alcuni progetti non sono attualmente sul branch di integrazione")
 
-	selectedIndices, err := showBranchSwitchingTable(nonDevelopProjects)
+	selectedIndices, err := showBranchSwitchingTable(nonIntegrationProjects)
 	if err != nil {
 		pterm.Error.Println("Errore nella selezione interattiva:", err)
 		return err
 	}
 
 	// Aggiorna le informazioni in base alla selezione dell'utente
-	updateBranchSwitchingChoices(projectInfos, nonDevelopProjects, selectedIndices)
+	updateBranchSwitchingChoices(projectInfos, nonIntegrationProjects, selectedIndices)
 
 	return nil
 }
 
-// filterNonDevelopProjects filtra i progetti che non sono sul branch develop
-func filterNonDevelopProjects(projectInfos []ProjectInfo) []ProjectInfo {
-	nonDevelopProjects := make([]ProjectInfo, 0)
+// filterNonIntegrationProjects filtra i progetti che non sono sul branch di integrazione
+func filterNonIntegrationProjects(projectInfos []ProjectInfo) []ProjectInfo {
+	nonIntegrationProjects := make([]ProjectInfo, 0)
 	for _, pInfo := range projectInfos {
-		if !pInfo.IsDevelop {
-			nonDevelopProjects = append(nonDevelopProjects, pInfo)
+		if !pInfo.IsIntegration {
+			nonIntegrationProjects = append(nonIntegrationProjects, pInfo)
 		}
 	}
-	return nonDevelopProjects
+	return nonIntegrationProjects
 }
 
-// showBranchSwitchingTable mostra una tabella interattiva per selezionare i progetti da passare a develop
-func showBranchSwitchingTable(nonDevelopProjects []ProjectInfo) ([]int, error) {
+// showBranchSwitchingTable mostra una tabella interattiva per selezionare i progetti da passare al branch di integrazione
+func showBranchSwitchingTable(nonIntegrationProjects []ProjectInfo) ([]int, error) {
 	// Prepara le opzioni formattate per pterm
-	options := make([]string, len(nonDevelopProjects))
+	options := make([]string, len(nonIntegrationProjects))
 	defaultOptions := make([]string, 0)
 
-	// Trova la larghezza massima del nome progetto per allineamento
-	maxNameLen := 0
-	for _, pInfo := range nonDevelopProjects {
-		if len(pInfo.Name) > maxNameLen {
-			maxNameLen = len(pInfo.Name)
-		}
+	// Trova la larghezza massima del nome progetto e del branch per allineamento
+	maxNameLen, maxBranchLen := 0, 0
+	for _, pInfo := range nonIntegrationProjects {
+		maxNameLen = max(maxNameLen, len(pInfo.Name))
+		maxBranchLen = max(maxBranchLen, len(pInfo.CurrentBranch))
 	}
 
-	// Formatta ogni opzione come "Progetto │ Branch Attuale"
-	for i, pInfo := range nonDevelopProjects {
-		options[i] = fmt.Sprintf("%-*s │ %s", maxNameLen, pInfo.Name, pInfo.CurrentBranch)
-		if pInfo.switchToDevelop {
+	// Formatta ogni opzione come "Progetto │ Branch Attuale → Branch di integrazione"
+	for i, pInfo := range nonIntegrationProjects {
+		options[i] = fmt.Sprintf("%-*s │ %-*s → %s", maxNameLen, pInfo.Name, maxBranchLen, pInfo.CurrentBranch, pInfo.Policy.IntegrationBranch)
+		if pInfo.switchToIntegration {
 			defaultOptions = append(defaultOptions, options[i])
 		}
 	}
 
 	// Mostra header e selezione interattiva con pterm nativo
-	pterm.Info.Println("Seleziona i progetti da passare a 'develop':")
+	pterm.Info.Println("Seleziona i progetti da passare al branch di integrazione:")
 	pterm.Println()
 
 	selectedOptions, err := pterm.DefaultInteractiveMultiselect.
@@ -173,12 +175,12 @@ func showBranchSwitchingTable(nonDevelopProjects []ProjectInfo) ([]int, error) {
 }
 
 // updateBranchSwitchingChoices aggiorna le scelte di cambio branch in base alla selezione utente
-func updateBranchSwitchingChoices(projectInfos []ProjectInfo, nonDevelopProjects []ProjectInfo, selectedIndices []int) {
+func updateBranchSwitchingChoices(projectInfos []ProjectInfo, nonIntegrationProjects []ProjectInfo, selectedIndices []int) {
 	// Crea una mappa per lookup rapido
 	projectIndexMap := make(map[string]int)
 	for i := range projectInfos {
-		if !projectInfos[i].IsDevelop {
-			for idx, p := range nonDevelopProjects {
+		if !projectInfos[i].IsIntegration {
+			for idx, p := range nonIntegrationProjects {
 				if p.Name == projectInfos[i].Name {
 					projectIndexMap[p.Name] = idx
 					break
@@ -187,12 +189,12 @@ func updateBranchSwitchingChoices(projectInfos []ProjectInfo, nonDevelopProjects
 		}
 	}
 
-	// Aggiorna i flag switchToDevelop
+	// Aggiorna i flag switchToIntegration
 	for i := range projectInfos {
-		if !projectInfos[i].IsDevelop {
+		if !projectInfos[i].IsIntegration {
 			idx, exists := projectIndexMap[projectInfos[i].Name]
 			if exists {
-				projectInfos[i].switchToDevelop = slices.Contains(selectedIndices, idx)
+				projectInfos[i].switchToIntegration = slices.Contains(selectedIndices, idx)
 			}
 		}
 	}
@@ -231,8 +233,8 @@ func processProject(pInfo *ProjectInfo) error {
 	}
 
 	// 2. Cambia branch se richiesto
-	if pInfo.switchToDevelop {
-		if err := switchToDevelopBranch(pInfo); err != nil {
+	if pInfo.switchToIntegration {
+		if err := switchToIntegrationBranch(pInfo); err != nil {
 			return err
 		}
 	}
@@ -293,21 +295,22 @@ func stashUncommittedChanges(projectPath string) (bool, error) {
 	return true, nil
 }
 
-// switchToDevelopBranch cambia il branch corrente a develop
-func switchToDevelopBranch(pInfo *ProjectInfo) error {
-	pterm.Info.Println("Cambio branch a 'develop'...")
+// switchToIntegrationBranch cambia il branch corrente a quello di integrazione
+func switchToIntegrationBranch(pInfo *ProjectInfo) error {
+	branch := pInfo.Policy.IntegrationBranch
+	pterm.Info.Printf("Cambio branch a '%s'...\n", branch)
 
-	if err := exec.Run("git", "-C", pInfo.Path, "checkout", "develop"); err != nil {
+	if err := exec.Run("git", "-C", pInfo.Path, "checkout", branch); err != nil {
 		pterm.Error.Println("Errore durante il cambio branch")
 		return fmt.Errorf("errore durante il cambio branch: %w", err)
 	}
 
-	pterm.Success.Println("Branch cambiato a 'develop'")
+	pterm.Success.Printf("Branch cambiato a '%s'\n", branch)
 
 	// Aggiorna le informazioni del progetto
-	pInfo.IsDevelop = true
-	pInfo.IsDeploy = false
-	pInfo.CurrentBranch = "develop"
+	pInfo.IsIntegration = true
+	pInfo.IsRelease = false
+	pInfo.CurrentBranch = branch
 
 	return nil
 }
@@ -317,28 +320,16 @@ func updateRepository(pInfo *ProjectInfo) error {
 	pterm.Info.Println("Aggiornamento del repository...")
 
 	switch {
-	case pInfo.IsDevelop:
-		return updateDevelopBranch(pInfo)
-	case pInfo.IsDeploy:
-		return updateDeployBranch(pInfo)
+	case pInfo.IsIntegration, pInfo.IsRelease:
+		return pullCurrentBranch(pInfo)
 	default:
 		return updateFeatureBranch(pInfo)
 	}
 }
 
-// updateDevelopBranch esegue git pull per il branch develop
-func updateDevelopBranch(pInfo *ProjectInfo) error {
-	if err := exec.Run("git", "-C", pInfo.Path, "pull", "origin", "develop"); err != nil {
-		pterm.Error.Println("Errore durante il git pull")
-		return fmt.Errorf("errore durante il git pull: %w", err)
-	}
-	pterm.Success.Println("Git pull eseguito con successo sul branch 'develop'")
-	return nil
-}
-
-// updateDeployBranch esegue git pull per un branch deploy/*
-func updateDeployBranch(pInfo *ProjectInfo) error {
-	if err := exec.Run("git", "-C", pInfo.Path, "pull", "origin", pInfo.CurrentBranch); err != nil {
+// pullCurrentBranch esegue git pull per il branch di integrazione o per un branch di rilascio
+func pullCurrentBranch(pInfo *ProjectInfo) error {
+	if err := exec.Run("git", "-C", pInfo.Path, "pull", pInfo.Policy.Remote, pInfo.CurrentBranch); err != nil {
 		pterm.Error.Println("Errore durante il git pull")
 		return fmt.Errorf("errore durante il git pull: %w", err)
 	}
@@ -346,21 +337,23 @@ func updateDeployBranch(pInfo *ProjectInfo) error {
 	return nil
 }
 
-// updateFeatureBranch esegue git fetch + git merge di develop per feature branch
+// updateFeatureBranch esegue git fetch + git merge del branch di integrazione per feature branch
 func updateFeatureBranch(pInfo *ProjectInfo) error {
-	// Fetch delle modifiche da develop
-	if err := exec.Run("git", "-C", pInfo.Path, "fetch", "origin", "develop"); err != nil {
+	remote, branch := pInfo.Policy.Remote, pInfo.Policy.IntegrationBranch
+
+	// Fetch delle modifiche del branch di integrazione
+	if err := exec.Run("git", "-C", pInfo.Path, "fetch", remote, branch); err != nil {
 		pterm.Error.Println("Errore durante il git fetch")
 		return fmt.Errorf("errore durante il git fetch: %w", err)
 	}
 
-	// Merge di develop nel branch corrente
-	if err := exec.Run("git", "-C", pInfo.Path, "merge", "origin/develop"); err != nil {
+	// Merge del branch di integrazione nel branch corrente
+	if err := exec.Run("git", "-C", pInfo.Path, "merge", remote+"/"+branch); err != nil {
 		pterm.Error.Println("Errore durante il git merge")
 		return fmt.Errorf("errore durante il git merge: %w", err)
 	}
 
-	pterm.Success.Printf("Fetch e merge di 'develop' eseguiti con successo sul branch '%s'\n", pInfo.CurrentBranch)
+	pterm.Success.Printf("Fetch e merge di '%s' eseguiti con successo sul branch '%s'\n", branch, pInfo.CurrentBranch)
 	return nil
 }
 
@@ -377,17 +370,17 @@ func popStash(projectPath string) error {
 	return nil
 }
 
-// branchInformation recupera le informazioni sul branch corrente di un progetto
-func branchInformation(projectPath string) (isDevelop, isDeploy bool, currentBranch string, err error) {
+// branchInformation recupera le informazioni sul branch corrente di un progetto secondo il suo modello di branch
+func branchInformation(projectPath string, policy config.BranchingPolicy) (isIntegration, isRelease bool, currentBranch string, err error) {
 	currentBranch, err = exec.RunWithOutput("git", "-C", projectPath, "branch", "--show-current")
 	if err != nil {
 		return false, false, "", fmt.Errorf("impossibile recuperare il branch corrente: %w", err)
 	}
 
-	isDevelop = currentBranch == "develop"
-	isDeploy = strings.HasPrefix(currentBranch, "deploy/")
+	isIntegration = policy.IsIntegrationBranch(currentBranch)
+	isRelease = !isIntegration && policy.IsReleaseBranch(currentBranch)
 
-	return isDevelop, isDeploy, currentBranch, nil
+	return isIntegration, isRelease, currentBranch, nil
 }
 
 func init() {
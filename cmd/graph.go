@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/cmdutil"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// Formati di esportazione supportati dal comando graph
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+	graphFormatJSON    = "json"
+	graphFormatTree    = "tree"
+)
+
+var (
+	graphFormat          string
+	graphUpstream        []string
+	graphDownstream      []string
+	graphHighlightCycles bool
+	graphKinds           []string
+	graphOutput          string
+)
+
+// graphCmd rappresenta il comando graph per esportare il grafo delle dipendenze
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Esporta il grafo delle dipendenze dei progetti selezionati",
+	Long: `Costruisce il grafo delle dipendenze Maven dei progetti selezionati nel profilo attivo
+e lo stampa nel formato richiesto: Graphviz DOT, Mermaid, JSON (lista di adiacenza) o albero ASCII.
+Gli archi vanno dal progetto alla sua dipendenza e sono etichettati con il tipo di dipendenza
+(dependency, parent, import, plugin, extension).
+
+Il grafo può essere limitato alle dipendenze di un progetto (--upstream) o ai progetti
+che dipendono da esso (--downstream). Con --highlight-cycles i cicli vengono evidenziati.
+
+Esempi:
+  projman graph                                - Albero ASCII delle dipendenze
+  projman graph -f dot | dot -Tsvg > deps.svg  - Immagine SVG tramite Graphviz
+  projman graph -f mermaid --downstream core   - Progetti impattati da una modifica a 'core'
+  projman graph -f json -o deps.json           - Salva il grafo in formato JSON`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadSettings()
+		if err != nil {
+			pterm.Error.Println("Errore nel caricamento della configurazione:", err)
+			return err
+		}
+		// Il grafo non compila nulla: i progetti selezionati non più risolti vengono solo esclusi
+		unresolved, err := cfg.RefreshProjectsForReading()
+		if err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+		if len(unresolved) > 0 {
+			pterm.Warning.Printf("Progetti selezionati non trovati nelle directory root, esclusi dal grafo: %s\n",
+				strings.Join(unresolved, ", "))
+		}
+
+		kindNames := graphKinds
+		if len(kindNames) == 0 {
+			kindNames = cfg.EdgeKinds
+		}
+		edgeKinds, err := buildsystem.ParseEdgeKinds(kindNames)
+		if err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+
+		analysis, err := cmdutil.AnalyzeProjects(&cfg)
+		if err != nil {
+			pterm.Error.Println("Errore durante l'analisi delle dipendenze:", err)
+			return err
+		}
+		analysis = analysis.Filter(edgeKinds)
+
+		dependencyGraph, err := analysis.Graph.Restrict(graphUpstream, graphDownstream)
+		if err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+
+		opts := graph.ExportOptions{
+			EdgeLabels: func(edge graph.Edge) []string {
+				kinds := analysis.EdgeKinds(edge)
+				labels := make([]string, len(kinds))
+				for i, kind := range kinds {
+					labels[i] = string(kind)
+				}
+				return labels
+			},
+			HighlightCycles: graphHighlightCycles,
+		}
+
+		output, err := renderGraph(dependencyGraph, graphFormat, opts)
+		if err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+
+		if graphOutput == "" {
+			fmt.Print(output)
+			return nil
+		}
+
+		if err := os.WriteFile(graphOutput, []byte(output), config.ConfigFilePermissions); err != nil {
+			pterm.Error.Println("Errore durante il salvataggio del grafo:", err)
+			return err
+		}
+		pterm.Success.Printf("Grafo salvato in %s\n", graphOutput)
+		return nil
+	},
+}
+
+// renderGraph esporta il grafo nel formato richiesto
+func renderGraph(dependencyGraph graph.DependencyGraph, format string, opts graph.ExportOptions) (string, error) {
+	switch strings.ToLower(format) {
+	case graphFormatDOT:
+		return dependencyGraph.DOT(opts), nil
+	case graphFormatMermaid:
+		return dependencyGraph.Mermaid(opts), nil
+	case graphFormatJSON:
+		data, err := dependencyGraph.JSON(opts)
+		if err != nil {
+			return "", fmt.Errorf("impossibile serializzare il grafo: %w", err)
+		}
+		return string(data) + "\n", nil
+	case graphFormatTree:
+		return dependencyGraph.Tree(opts), nil
+	default:
+		return "", fmt.Errorf("formato '%s' non supportato (valori ammessi: %s, %s, %s, %s)",
+			format, graphFormatDOT, graphFormatMermaid, graphFormatJSON, graphFormatTree)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", graphFormatTree, "Formato di output: dot, mermaid, json, tree")
+	graphCmd.Flags().StringSliceVarP(&graphUpstream, "upstream", "u", nil, "Mostra solo il progetto e le sue dipendenze (transitive)")
+	graphCmd.Flags().StringSliceVarP(&graphDownstream, "downstream", "d", nil, "Mostra solo il progetto e i progetti che dipendono da esso (transitivamente)")
+	graphCmd.Flags().BoolVar(&graphHighlightCycles, "highlight-cycles", false, "Evidenzia i progetti e le dipendenze che formano cicli")
+	graphCmd.Flags().StringSliceVar(&graphKinds, "kinds", nil, "Tipi di dipendenza da mostrare (dependency, parent, import, plugin, extension)")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "Salva il grafo nel file indicato invece di stamparlo")
+}
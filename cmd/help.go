@@ -36,11 +36,16 @@ Se viene passato il nome di un comando, mostra informazioni specifiche su quel c
 		pterm.DefaultSection.Println("COMANDI DISPONIBILI")
 		tableData := pterm.TableData{
 			{"COMANDO", "DESCRIZIONE"},
-			{"init [directory]", "Scansiona la directory e seleziona i progetti Maven da gestire"},
+			{"init <profilo> <directory...>", "Scansiona la directory e seleziona i progetti Maven da gestire"},
+			{"priority [progetti]", "Imposta i progetti da elaborare per primi a parità di dipendenze"},
+			{"graph", "Esporta il grafo delle dipendenze (dot, mermaid, json, tree)"},
+			{"logs [progetto]", "Mostra i log delle build Maven delle esecuzioni precedenti"},
 			{"git", "Gestisce le operazioni Git sui progetti selezionati"},
 			{"git update", "Esegue git pull/merge su tutti i progetti con gestione intelligente dei branch"},
 			{"mvn", "Esegue comandi Maven sui progetti selezionati"},
 			{"mvn install", "Esegue mvn install su tutti i progetti (usa --tests per abilitare i test)"},
+			{"mvn run -- <goal>", "Esegue goal Maven arbitrari sui progetti (--unordered per eseguirli in parallelo)"},
+			{"mvn stats", "Mostra durate medie, trend e moduli più lenti delle build"},
 			{"help", "Mostra questa guida"},
 		}
 		_ = pterm.DefaultTable.WithHasHeader(true).WithBoxed(true).WithData(tableData).Render()
@@ -50,8 +55,12 @@ Se viene passato il nome di un comando, mostra informazioni specifiche su quel c
 		pterm.DefaultSection.Println("COMANDO: init")
 		pterm.FgGray.Println("  Inizializza la configurazione di projman")
 		initDetails := []pterm.BulletListItem{
-			{Level: 0, Text: "Scansiona la directory specificata alla ricerca di progetti Maven (pom.xml)", Bullet: "•"},
+			{Level: 0, Text: "Scansiona la directory specificata alla ricerca di progetti Maven (pom.xml), Gradle (settings.gradle, build.gradle) e npm (package.json)", Bullet: "•"},
+			{Level: 0, Text: "Ricerca ricorsiva fino a --depth livelli (default 3), senza scendere nei progetti trovati", Bullet: "•"},
+			{Level: 0, Text: "Salta directory nascoste, target/, node_modules/ e i pattern del file .projmanignore", Bullet: "•"},
+			{Level: 0, Text: "Accetta più directory root: i percorsi presenti in più root diventano <root>:<percorso>", Bullet: "•"},
 			{Level: 0, Text: "Permette selezione interattiva dei progetti da gestire", Bullet: "•"},
+			{Level: 0, Text: "Chiede le opzioni Maven del profilo (profili, settings.xml, repository locale, offline, proprietà)", Bullet: "•"},
 			{Level: 0, Text: "Salva la configurazione in ~/.config/projman/projman_config.json", Bullet: "•"},
 		}
 		_ = pterm.DefaultBulletList.WithItems(initDetails).Render()
@@ -62,9 +71,10 @@ Se viene passato il nome di un comando, mostra informazioni specifiche su quel c
 		pterm.FgGray.Println("  Aggiorna tutti i progetti con gestione intelligente dei branch")
 		gitDetails := []pterm.BulletListItem{
 			{Level: 0, Text: "Stash automatico delle modifiche non committate", Bullet: "•"},
-			{Level: 0, Text: "Selezione interattiva dei progetti da passare a 'develop'", Bullet: "•"},
-			{Level: 0, Text: "Pull da origin per branch develop e deploy/*", Bullet: "•"},
-			{Level: 0, Text: "Fetch + merge di develop per altri branch", Bullet: "•"},
+			{Level: 0, Text: "Selezione interattiva dei progetti da passare al branch di integrazione", Bullet: "•"},
+			{Level: 0, Text: "Pull dal remote per il branch di integrazione e i branch di rilascio (default develop, deploy/*, origin)", Bullet: "•"},
+			{Level: 0, Text: "Fetch + merge del branch di integrazione per altri branch", Bullet: "•"},
+			{Level: 0, Text: "Modello di branch configurabile con git_branching nel profilo o nel singolo progetto", Bullet: "•"},
 			{Level: 0, Text: "Ripristino automatico dello stash", Bullet: "•"},
 		}
 		_ = pterm.DefaultBulletList.WithItems(gitDetails).Render()
@@ -77,6 +87,16 @@ Se viene passato il nome di un comando, mostra informazioni specifiche su quel c
 			{Level: 0, Text: "Default: test disabilitati (-DskipTests=true)", Bullet: "•"},
 			{Level: 0, Text: "Usa --tests o -t per abilitare l'esecuzione dei test", Bullet: "•"},
 			{Level: 0, Text: "Esegue in sequenza su tutti i progetti selezionati", Bullet: "•"},
+			{Level: 0, Text: "Usa --jobs N o -j N per compilare in parallelo i progetti indipendenti", Bullet: "•"},
+			{Level: 0, Text: "Usa --from P per installare P e i progetti che dipendono da esso, --upto P per P e le sue dipendenze", Bullet: "•"},
+			{Level: 0, Text: "Usa --resume per riprendere l'ultima esecuzione dal primo progetto fallito", Bullet: "•"},
+			{Level: 0, Text: "Usa --changed per ricompilare solo i progetti modificati dall'ultima build riuscita", Bullet: "•"},
+			{Level: 0, Text: "Usa -P a,b,!c, --settings, --local-repo, --offline e -D chiave=valore per sovrascrivere le opzioni Maven del profilo", Bullet: "•"},
+			{Level: 0, Text: "Usa il Maven Wrapper (mvnw) dei progetti che lo forniscono", Bullet: "•"},
+			{Level: 0, Text: "Compila ogni progetto con il JDK configurato o dichiarato (.sdkmanrc, .java-version, pom.xml)", Bullet: "•"},
+			{Level: 0, Text: "Mostra la durata stimata e il percorso critico in base alle build precedenti", Bullet: "•"},
+			{Level: 0, Text: "Compila anche i progetti Gradle (gradlew o gradle, task configurabili con gradle_tasks) nello stesso ordine", Bullet: "•"},
+			{Level: 0, Text: "Compila i pacchetti npm e pnpm con lo script configurato in npm_script (default: build)", Bullet: "•"},
 		}
 		_ = pterm.DefaultBulletList.WithItems(mvnDetails).Render()
 		pterm.Println()
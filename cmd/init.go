@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
@@ -9,31 +10,45 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var initDepth int
+
 // initCmd rappresenta il comando init per inizializzare la configurazione di projman
 var initCmd = &cobra.Command{
-	Use:   "init <nome-profilo> <directory_root_progetti>",
+	Use:   "init <nome-profilo> <directory_root_progetti> [altre directory root...]",
 	Short: "Seleziona i progetti da includere nella gestione",
 	Long: `Inizializza la configurazione di projman selezionando i progetti da gestire.
-Richiede il nome del profilo e il percorso della directory root contenente i progetti Maven.
-Permette di selezionare interattivamente quali progetti includere nella gestione.`,
-	Args: cobra.ExactArgs(2),
+Richiede il nome del profilo e il percorso della directory root contenente i progetti Maven;
+indicando più directory root il profilo gestisce insieme i progetti di tutte.
+Permette di selezionare interattivamente quali progetti includere nella gestione.
+
+I progetti vengono cercati ricorsivamente fino a --depth livelli sotto la root (default 3),
+senza scendere nei progetti già trovati. Vengono saltate le directory nascoste, target/,
+node_modules/ e quelle corrispondenti ai pattern glob del file .projmanignore nella root.
+Ogni progetto è identificato dal suo percorso relativo (es. backend/payments/api); se lo stesso
+percorso esiste in più directory root viene qualificato con il nome della root (es. platform:api).`,
+	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		profileName := args[0]
-		directory := args[1]
 
-		// Valida l'esistenza e validità della directory
-		root, err := config.CheckAndGetDirectory(directory)
-		if err != nil {
-			pterm.Error.Println("La directory specificata non è valida:", directory)
-			return err
+		// Valida l'esistenza e validità delle directory
+		roots := make([]string, 0, len(args)-1)
+		for _, directory := range args[1:] {
+			root, err := config.CheckAndGetDirectory(directory)
+			if err != nil {
+				pterm.Error.Println("La directory specificata non è valida:", directory)
+				return err
+			}
+			roots = append(roots, root)
 		}
 
-		// Scansiona la directory alla ricerca di progetti Maven
+		// Scansiona le directory alla ricerca di progetti Maven
 		pterm.Info.Println("Scansione dei progetti Maven in corso...")
 		pterm.Info.Printf("Profilo: %s\n", profileName)
-		pterm.Info.Printf("Directory: %s\n", root)
+		for _, root := range roots {
+			pterm.Info.Printf("Directory: %s\n", root)
+		}
 
-		projs, err := project.Discover(root)
+		projs, err := project.DiscoverAll(roots, initDepth)
 		if err != nil {
 			pterm.Error.Println("Errore durante la scansione dei progetti:", err)
 			return err
@@ -41,7 +56,7 @@ Permette di selezionare interattivamente quali progetti includere nella gestione
 
 		// Verifica che siano stati trovati progetti
 		if len(projs) == 0 {
-			pterm.Warning.Println("Nessun progetto Maven trovato nella directory specificata")
+			pterm.Warning.Println("Nessun progetto Maven trovato nelle directory specificate")
 			pterm.Info.Println("Assicurati che la directory contenga sottocartelle con file pom.xml")
 			return nil
 		}
@@ -65,32 +80,30 @@ Permette di selezionare interattivamente quali progetti includere nella gestione
 			return nil
 		}
 
-		// Prompt opzionale per il profilo Maven
-		mavenProfile, err := pterm.DefaultInteractiveTextInput.
-			WithDefaultText("").
-			Show("Inserisci il nome del profilo Maven (lascia vuoto per saltare):")
-		if err != nil {
-			pterm.Error.Println("Errore durante l'input del profilo Maven:", err)
-			return err
-		}
-		// Trim degli spazi bianchi
-		mavenProfile = strings.TrimSpace(mavenProfile)
-
-		// Salva la configurazione nel profilo specificato
+		// Prompt opzionali per le opzioni Maven del profilo
 		cfg := config.Config{
 			SelectedProjects: selectedNames,
-			RootOfProjects:   root,
-			MavenProfile:     mavenProfile,
+			RootOfProjects:   roots[0],
+			DiscoveryDepth:   initDepth,
+		}
+		if len(roots) > 1 {
+			cfg.Roots = roots
+		}
+		cfg.RememberRoots(projs, selectedNames)
+		if err := promptMavenOptions(&cfg); err != nil {
+			pterm.Error.Println("Errore durante l'input delle opzioni Maven:", err)
+			return err
 		}
 
+		// Salva la configurazione nel profilo specificato
 		if err := config.SaveProfile(profileName, cfg); err != nil {
 			pterm.Error.Println("Errore durante il salvataggio della configurazione:", err)
 			return err
 		}
 
-		// Messaggio di successo adattato in base alla presenza del profilo Maven
-		if mavenProfile != "" {
-			pterm.Success.Printf("Profilo '%s' configurato con %d progetti e profilo Maven '%s'\n", profileName, len(selectedNames), mavenProfile)
+		// Messaggio di successo adattato in base alla presenza dei profili Maven
+		if cfg.MavenProfile != "" {
+			pterm.Success.Printf("Profilo '%s' configurato con %d progetti e profili Maven '%s'\n", profileName, len(selectedNames), cfg.MavenProfile)
 		} else {
 			pterm.Success.Printf("Profilo '%s' configurato con %d progetti selezionati\n", profileName, len(selectedNames))
 		}
@@ -98,6 +111,62 @@ Permette di selezionare interattivamente quali progetti includere nella gestione
 	},
 }
 
+// promptMavenOptions chiede le opzioni Maven del profilo: profili, settings.xml,
+// repository locale, modalità offline e proprietà aggiuntive. Tutte sono facoltative.
+func promptMavenOptions(cfg *config.Config) error {
+	textInput := func(message string) (string, error) {
+		value, err := pterm.DefaultInteractiveTextInput.WithDefaultText("").Show(message)
+		return strings.TrimSpace(value), err
+	}
+
+	var err error
+	if cfg.MavenProfile, err = textInput("Inserisci i profili Maven separati da virgola, es. a,b,!c (lascia vuoto per saltare):"); err != nil {
+		return err
+	}
+	if cfg.MavenSettings, err = textInput("Percorso del file settings.xml (lascia vuoto per usare quello predefinito):"); err != nil {
+		return err
+	}
+	if cfg.LocalRepository, err = textInput("Repository locale Maven (lascia vuoto per usare ~/.m2/repository):"); err != nil {
+		return err
+	}
+	if cfg.Offline, err = pterm.DefaultInteractiveConfirm.WithDefaultValue(false).Show("Eseguire Maven in modalità offline?"); err != nil {
+		return err
+	}
+
+	// Una proprietà per richiesta, così i valori possono contenere spazi e virgole
+	for {
+		entry, err := textInput("Proprietà Maven aggiuntiva nella forma chiave=valore (lascia vuoto per terminare):")
+		if err != nil {
+			return err
+		}
+		if entry == "" {
+			break
+		}
+		name, value, err := parseMavenProperty(entry)
+		if err != nil {
+			pterm.Warning.Println(err)
+			continue
+		}
+		if cfg.MavenProperties == nil {
+			cfg.MavenProperties = make(map[string]string)
+		}
+		cfg.MavenProperties[name] = value
+	}
+	return nil
+}
+
+// parseMavenProperty separa una proprietà chiave=valore sul primo '=': il valore viene
+// conservato così com'è, spazi compresi
+func parseMavenProperty(entry string) (string, string, error) {
+	name, value, found := strings.Cut(entry, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("proprietà '%s' ignorata: usa la forma chiave=valore", entry)
+	}
+	return name, value, nil
+}
+
 func init() {
 	RootCmd.AddCommand(initCmd)
+	initCmd.Flags().IntVar(&initDepth, "depth", project.DefaultMaxDepth, "Profondità massima di ricerca dei progetti sotto la directory root")
 }
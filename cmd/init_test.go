@@ -0,0 +1,39 @@
+package cmd
+
+import "testing"
+
+// TestParseMavenProperty verifica che le proprietà inserite in init conservino spazi e '=' nel valore
+func TestParseMavenProperty(t *testing.T) {
+	tests := []struct {
+		entry string
+		name  string
+		value string
+		valid bool
+	}{
+		{entry: "skipITs=true", name: "skipITs", value: "true", valid: true},
+		{entry: "argLine=-Xmx1g -Dfile.encoding=UTF-8", name: "argLine", value: "-Xmx1g -Dfile.encoding=UTF-8", valid: true},
+		{entry: "db.url=jdbc:h2:mem:test;MODE=Oracle", name: "db.url", value: "jdbc:h2:mem:test;MODE=Oracle", valid: true},
+		{entry: "empty=", name: "empty", value: "", valid: true},
+		{entry: "skipITs", valid: false},
+		{entry: "=true", valid: false},
+		{entry: "skip ITs=true", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			name, value, err := parseMavenProperty(tt.entry)
+			if !tt.valid {
+				if err == nil {
+					t.Fatalf("parseMavenProperty(%q) = %q, %q, atteso errore", tt.entry, name, value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMavenProperty(%q) errore inatteso: %v", tt.entry, err)
+			}
+			if name != tt.name || value != tt.value {
+				t.Errorf("parseMavenProperty(%q) = %q, %q, attesi %q, %q", tt.entry, name, value, tt.name, tt.value)
+			}
+		})
+	}
+}
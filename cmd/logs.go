@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// logsFollowInterval è l'intervallo con cui viene controllato il file di log in modalità --follow
+const logsFollowInterval = 500 * time.Millisecond
+
+// maxListedRuns è il numero massimo di esecuzioni mostrate nell'elenco
+const maxListedRuns = 10
+
+var (
+	logsRunID  string
+	logsTail   int
+	logsFollow bool
+)
+
+// logsCmd rappresenta il comando logs per consultare i log delle build
+var logsCmd = &cobra.Command{
+	Use:   "logs [progetto]",
+	Short: "Mostra i log delle build Maven delle esecuzioni precedenti",
+	Long: `Ogni esecuzione di 'projman mvn install' salva l'output completo di Maven di ogni progetto
+in un file di log, in una directory dedicata all'esecuzione.
+
+Senza argomenti elenca i log dell'ultima esecuzione (o di quella indicata con --run)
+e le esecuzioni disponibili. Con il nome di un progetto stampa il suo log;
+--tail mostra solo le ultime righe e --follow continua a mostrare le righe aggiunte.
+
+Esempi:
+  projman logs                         - Elenca i log dell'ultima esecuzione
+  projman logs core                    - Mostra il log di 'core' nell'ultima esecuzione
+  projman logs core --tail 50          - Mostra le ultime 50 righe del log di 'core'
+  projman logs core -f                 - Segue il log di 'core' durante la build
+  projman logs core --run 20250101-093000.000 - Mostra il log di 'core' di un'esecuzione precedente`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataDir, err := config.ProfileDataDir()
+		if err != nil {
+			pterm.Error.Println("Errore nel caricamento della configurazione:", err)
+			return err
+		}
+
+		runs, err := runstate.ListRuns(dataDir)
+		if err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+		if len(runs) == 0 {
+			pterm.Info.Println("Nessun log disponibile: esegui prima 'projman mvn install'")
+			return nil
+		}
+
+		runID := logsRunID
+		if runID == "" {
+			runID = runs[0]
+		}
+
+		if len(args) == 0 {
+			return listLogs(dataDir, runID, runs)
+		}
+
+		log, err := runstate.FindLog(dataDir, runID, args[0])
+		if err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+		if err := printLog(log.Path, logsTail, logsFollow); err != nil {
+			pterm.Error.Println(err)
+			return err
+		}
+		return nil
+	},
+}
+
+// listLogs mostra i log dei progetti di un'esecuzione e l'elenco delle esecuzioni disponibili
+func listLogs(dataDir, runID string, runs []string) error {
+	logs, err := runstate.ListLogs(dataDir, runID)
+	if err != nil {
+		pterm.Error.Println(err)
+		return err
+	}
+
+	pterm.DefaultSection.Printf("Log dell'esecuzione %s", runID)
+	tableData := pterm.TableData{{"Progetto", "Ultima modifica", "Dimensione", "Percorso"}}
+	for _, log := range logs {
+		tableData = append(tableData, []string{
+			log.Project,
+			log.ModTime.Format("02/01/2006 15:04:05"),
+			fmt.Sprintf("%d KB", (log.Size+1023)/1024),
+			log.Path,
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+
+	if len(runs) > 1 {
+		listed := runs
+		if len(listed) > maxListedRuns {
+			listed = listed[:maxListedRuns]
+		}
+		pterm.Println()
+		pterm.Info.Printf("Esecuzioni disponibili: %s\n", strings.Join(listed, ", "))
+	}
+	return nil
+}
+
+// printLog stampa il file di log; con tail > 0 solo le ultime tail righe.
+// Con follow continua a stampare le righe aggiunte finché il comando non viene interrotto.
+func printLog(path string, tail int, follow bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("impossibile aprire il log: %w", err)
+	}
+	defer file.Close()
+
+	if tail > 0 {
+		lines := make([]string, 0, tail)
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if len(lines) == tail {
+				lines = lines[1:]
+			}
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("impossibile leggere il log: %w", err)
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	} else if _, err := io.Copy(os.Stdout, file); err != nil {
+		return fmt.Errorf("impossibile leggere il log: %w", err)
+	}
+
+	if !follow {
+		return nil
+	}
+
+	// Il file resta aperto alla posizione corrente: basta copiare i nuovi byte quando compaiono
+	for {
+		if _, err := io.Copy(os.Stdout, file); err != nil {
+			return fmt.Errorf("impossibile leggere il log: %w", err)
+		}
+		time.Sleep(logsFollowInterval)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(logsCmd)
+	logsCmd.Flags().StringVar(&logsRunID, "run", "", "Identificativo dell'esecuzione (default: la più recente)")
+	logsCmd.Flags().IntVarP(&logsTail, "tail", "n", 0, "Mostra solo le ultime N righe del log")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Continua a mostrare le righe aggiunte al log")
+}
@@ -0,0 +1,78 @@
+package mvn
+
+import (
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/cmdutil"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/gradle"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/npm"
+)
+
+// defaultGradleTasks sono i task Gradle eseguiti da mvn install se il profilo non ne configura altri
+var defaultGradleTasks = []string{"clean", "build"}
+
+// defaultNpmScript è lo script eseguito da mvn install sui pacchetti npm se il profilo non ne configura un altro
+const defaultNpmScript = "build"
+
+// installTasks restituisce i task eseguiti da mvn install sui progetti non Maven del build system indicato
+func installTasks(cfg *config.Config, system buildsystem.BuildSystem) []string {
+	switch system.Name() {
+	case gradle.Name:
+		if len(cfg.GradleTasks) > 0 {
+			return cfg.GradleTasks
+		}
+		return defaultGradleTasks
+	case npm.Name:
+		if cfg.NpmScript != "" {
+			return []string{cfg.NpmScript}
+		}
+		return []string{defaultNpmScript}
+	default:
+		return nil
+	}
+}
+
+// projectBuildSystem restituisce il build system di un progetto selezionato. Come nell'analisi delle
+// dipendenze Maven ha la precedenza; una directory non riconosciuta viene trattata come progetto Maven.
+func projectBuildSystem(cfg *config.Config, projectName string) buildsystem.BuildSystem {
+	mavenSystem := maven.BuildSystem{Launcher: mavenLauncher(cfg), PhaseRules: phaseRules(cfg)}
+	systems := append([]buildsystem.BuildSystem{mavenSystem}, cmdutil.OtherBuildSystems...)
+	system, found := buildsystem.Detect(cfg.ProjectDir(projectName), systems...)
+	if !found {
+		return mavenSystem
+	}
+	return system
+}
+
+// projectBuild è la build di un progetto: il build system che la esegue e ne interpreta l'output e il comando
+type projectBuild struct {
+	system  buildsystem.BuildSystem
+	command buildsystem.Command
+}
+
+// projectCommand restituisce la build di un progetto selezionato: i goal con le opzioni Maven del profilo
+// per i progetti Maven, i task configurati (vedi installTasks) per quelli degli altri build system
+func projectCommand(cfg *config.Config, projectName string, goals []string, skipTests bool,
+	opts mavenOptions) projectBuild {
+	dir := cfg.ProjectDir(projectName)
+	system := projectBuildSystem(cfg, projectName)
+	if system.Name() != maven.Name {
+		command := system.BuildCommand(dir, installTasks(cfg, system), buildsystem.BuildOptions{SkipTests: skipTests})
+		return projectBuild{system: system, command: command}
+	}
+	command := system.BuildCommand(dir, goals, buildsystem.BuildOptions{SkipTests: skipTests, Args: opts.args()})
+	return projectBuild{system: system, command: command}
+}
+
+// splitByBuildSystem divide i progetti in progetti Maven e progetti degli altri build system, mantenendo l'ordine
+func splitByBuildSystem(cfg *config.Config, projects []string) (mavenProjects, otherProjects []string) {
+	for _, projectName := range projects {
+		if projectBuildSystem(cfg, projectName).Name() == maven.Name {
+			mavenProjects = append(mavenProjects, projectName)
+		} else {
+			otherProjects = append(otherProjects, projectName)
+		}
+	}
+	return mavenProjects, otherProjects
+}
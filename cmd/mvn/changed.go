@@ -0,0 +1,126 @@
+package mvn
+
+import (
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/fingerprint"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/pterm/pterm"
+)
+
+// loadFingerprints carica i fingerprint delle ultime build riuscite del profilo corrente
+func loadFingerprints() (*fingerprint.Store, error) {
+	dataDir, err := config.ProfileDataDir()
+	if err != nil {
+		return nil, err
+	}
+	return fingerprint.Load(dataDir)
+}
+
+// planChangedProjects confronta il fingerprint di ogni progetto con quello dell'ultima build riuscita,
+// e la data di quest'ultima con quelle delle sue dipendenze, e restringe l'analisi ai progetti cambiati e a quelli che dipendono da essi.
+// Stampa il piano indicando per ogni progetto se verrà ricompilato e perché.
+func planChangedProjects(cfg *config.Config, analysis *buildsystem.DependencyAnalysis, sortedProjects []string,
+	store *fingerprint.Store, opts mavenOptions) (*buildsystem.DependencyAnalysis, error) {
+	changed := make([]string, 0)
+	reasons := make(map[string]string, len(sortedProjects))
+	for _, projectName := range sortedProjects {
+		current, err := computeFingerprint(cfg, projectName, installCommand(cfg, projectName, opts))
+		if err != nil {
+			changed = append(changed, projectName)
+			reasons[projectName] = "fingerprint non disponibile: " + err.Error()
+			continue
+		}
+
+		previous, found := store.Get(projectName)
+		isChanged, reason := current.Compare(previous, found)
+		if !isChanged {
+			// Una dipendenza ricompilata dopo il progetto rende obsoleta anche la sua ultima build
+			if stale, staleReason := store.CompareDependencies(projectName, analysis.Graph[projectName]); stale {
+				isChanged, reason = true, staleReason
+			}
+		}
+		if isChanged {
+			changed = append(changed, projectName)
+		}
+		reasons[projectName] = reason
+	}
+
+	restricted, err := changedClosure(analysis, changed)
+	if err != nil {
+		return nil, err
+	}
+
+	isChanged := make(map[string]bool, len(changed))
+	for _, projectName := range changed {
+		isChanged[projectName] = true
+	}
+
+	pterm.Info.Println("\nPiano incrementale:")
+	for _, projectName := range sortedProjects {
+		_, rebuilt := restricted.Graph[projectName]
+		switch {
+		case isChanged[projectName]:
+			pterm.Info.Printf("  ↻ %s da ricompilare: %s\n", projectName, reasons[projectName])
+		case rebuilt:
+			pterm.Info.Printf("  ↻ %s da ricompilare: dipende da %s\n", projectName, rebuildCause(restricted.Graph, projectName, isChanged))
+		default:
+			pterm.Info.Printf("  ✓ %s aggiornato: %s\n", projectName, reasons[projectName])
+		}
+	}
+	pterm.Println()
+
+	return restricted, nil
+}
+
+// changedClosure restringe l'analisi ai progetti cambiati e a quelli che dipendono da essi.
+// Se nessun progetto è cambiato restituisce un'analisi vuota.
+func changedClosure(analysis *buildsystem.DependencyAnalysis, changed []string) (*buildsystem.DependencyAnalysis, error) {
+	if len(changed) == 0 {
+		return &buildsystem.DependencyAnalysis{
+			Graph:    graph.NewDependencyGraph(),
+			Origins:  analysis.Origins,
+			Warnings: analysis.Warnings,
+		}, nil
+	}
+	return analysis.Restrict(nil, changed)
+}
+
+// rebuildCause restituisce la dipendenza per cui un progetto non modificato va ricompilato:
+// una dipendenza cambiata se presente, altrimenti una dipendenza a sua volta ricompilata
+func rebuildCause(restricted graph.DependencyGraph, projectName string, isChanged map[string]bool) string {
+	deps := restricted[projectName]
+	for _, dep := range deps {
+		if isChanged[dep] {
+			return dep
+		}
+	}
+	for _, dep := range deps {
+		if _, rebuilt := restricted[dep]; rebuilt {
+			return dep
+		}
+	}
+	return "un progetto ricompilato"
+}
+
+// captureFingerprint calcola il fingerprint del progetto prima della build e restituisce
+// la funzione da chiamare dopo una build riuscita per registrarlo (nessuna operazione se store è nil)
+func captureFingerprint(store *fingerprint.Store, cfg *config.Config, projectName string, build projectBuild) func() {
+	if store == nil {
+		return func() {}
+	}
+	fp, err := computeFingerprint(cfg, projectName, build)
+	return func() {
+		if err != nil {
+			return // Progetto fuori da un repository git: nessun fingerprint da registrare
+		}
+		if err := store.Record(projectName, fp); err != nil {
+			pterm.Warning.Println("Impossibile registrare il fingerprint della build:", err)
+		}
+	}
+}
+
+// computeFingerprint calcola il fingerprint di un progetto per la build indicata
+func computeFingerprint(cfg *config.Config, projectName string, build projectBuild) (fingerprint.Fingerprint, error) {
+	return fingerprint.Compute(cfg.ProjectDir(projectName), build.system.Name(), build.command.Args)
+}
@@ -0,0 +1,65 @@
+package mvn
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+)
+
+// TestChangedClosure verifica i progetti da ricompilare con --changed e il motivo riportato nel piano
+func TestChangedClosure(t *testing.T) {
+	// core <- service <- app; tools non ha dipendenze
+	analysis := &buildsystem.DependencyAnalysis{Graph: graph.NewDependencyGraph()}
+	analysis.Graph.AddNode("core", nil)
+	analysis.Graph.AddNode("tools", nil)
+	analysis.Graph.AddNode("service", []string{"core"})
+	analysis.Graph.AddNode("app", []string{"tools", "service"})
+
+	tests := []struct {
+		name    string
+		changed []string
+		rebuilt []string
+		causes  map[string]string
+	}{
+		{name: "nessun progetto cambiato", changed: nil, rebuilt: []string{}},
+		{
+			name:    "foglia cambiata",
+			changed: []string{"core"},
+			rebuilt: []string{"app", "core", "service"},
+			causes:  map[string]string{"service": "core", "app": "service"},
+		},
+		{
+			name:    "dipendenza cambiata preferita",
+			changed: []string{"core", "service"},
+			rebuilt: []string{"app", "core", "service"},
+			causes:  map[string]string{"app": "service"},
+		},
+		{name: "progetto senza dipendenze", changed: []string{"tools"}, rebuilt: []string{"app", "tools"}, causes: map[string]string{"app": "tools"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restricted, err := changedClosure(analysis, tt.changed)
+			if err != nil {
+				t.Fatalf("changedClosure() errore inatteso: %v", err)
+			}
+			if nodes := restricted.Graph.Nodes(); !reflect.DeepEqual(nodes, tt.rebuilt) {
+				t.Errorf("progetti da ricompilare = %v, attesi %v", nodes, tt.rebuilt)
+			}
+
+			isChanged := make(map[string]bool)
+			for _, projectName := range tt.changed {
+				isChanged[projectName] = true
+			}
+			for projectName, cause := range tt.causes {
+				if got := rebuildCause(restricted.Graph, projectName, isChanged); got != cause {
+					t.Errorf("rebuildCause(%s) = %s, atteso %s", projectName, got, cause)
+				}
+			}
+			// Un progetto cambiato senza dipendenze non deve far fallire il calcolo del motivo
+			_ = rebuildCause(restricted.Graph, "tools", isChanged)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package mvn
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/history"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+)
+
+// loadHistory carica lo storico delle durate delle build del profilo corrente
+func loadHistory() (*history.Store, error) {
+	dataDir, err := config.ProfileDataDir()
+	if err != nil {
+		return nil, err
+	}
+	return history.Load(dataDir)
+}
+
+// recordHistory aggiunge allo storico la durata della build di un progetto e delle sue fasi.
+// Un errore di salvataggio viene solo segnalato: non deve interrompere l'esecuzione.
+// Con store nil non registra nulla.
+func recordHistory(store *history.Store, run *runstate.Run, projectName, system string, startedAt time.Time,
+	buildExec *executor.Executor, buildErr error) {
+	if store == nil {
+		return
+	}
+	timings := buildExec.PhaseTimings()
+	phases := make([]history.PhaseRecord, len(timings))
+	for i, timing := range timings {
+		phases[i] = history.PhaseRecord{Name: timing.Name, Module: timing.Module, Duration: timing.Duration}
+	}
+
+	err := store.Add(history.Record{
+		RunID:     run.ID,
+		Project:   projectName,
+		System:    system,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+		Succeeded: buildErr == nil,
+		Phases:    phases,
+	})
+	if err != nil {
+		pterm.Warning.Println(err)
+	}
+}
+
+// printEstimate stima la durata dell'esecuzione dalle build precedenti e mostra il percorso critico,
+// cioè la catena di dipendenze che determina la durata minima con compilazione parallela.
+// I progetti già installati nell'esecuzione ripresa non vengono considerati.
+func printEstimate(store *history.Store, run *runstate.Run, dependencyGraph graph.DependencyGraph,
+	priority []string, jobs int) {
+	pending := make([]string, 0, len(dependencyGraph))
+	for projectName := range dependencyGraph {
+		if run.Status(projectName) != runstate.StatusSucceeded {
+			pending = append(pending, projectName)
+		}
+	}
+	pendingGraph := dependencyGraph.Subgraph(pending)
+
+	estimates := store.Estimates()
+	missing := 0
+	for projectName := range pendingGraph {
+		if _, found := estimates[projectName]; !found {
+			missing++
+		}
+	}
+	if missing == len(pendingGraph) {
+		return
+	}
+
+	total, err := pendingGraph.EstimateSchedule(jobs, priority, estimates)
+	if err != nil {
+		return
+	}
+	path, pathDuration, err := pendingGraph.CriticalPath(estimates)
+	if err != nil {
+		return
+	}
+
+	pterm.Info.Printf("Durata stimata: %s\n", formatDuration(total))
+	if len(path) > 1 {
+		pterm.Info.Printf("Percorso critico (%s): %s\n", formatDuration(pathDuration), strings.Join(path, " → "))
+	}
+	if missing > 0 {
+		pterm.Info.Printf("Stima parziale: %d progetti senza build precedenti riuscite\n", missing)
+	}
+}
+
+// formatDuration formatta una durata in minuti e secondi (in secondi se inferiore al minuto)
+func formatDuration(duration time.Duration) string {
+	if duration < time.Minute {
+		return fmt.Sprintf("%.1fs", duration.Seconds())
+	}
+	return fmt.Sprintf("%dm %ds", int(duration.Minutes()), int(duration.Seconds())%60)
+}
@@ -1,18 +1,34 @@
 package mvn
 
 import (
-	"path/filepath"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/cmdutil"
-	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/exec"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/events"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/jdk"
 	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven"
-	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven/executor"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
 var runTests bool
-var mavenProfile string
+var installMavenFlags mavenFlags
+var jobs int
+var installFrom []string
+var installUpTo []string
+var resumeRun bool
+var onlyChanged bool
+var outputFormat string
+
+// Formati di output del comando install
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
 
 // installCmd rappresenta il comando per eseguire mvn install sui progetti selezionati
 var installCmd = &cobra.Command{
@@ -21,21 +37,74 @@ var installCmd = &cobra.Command{
 	Long: `Esegue il comando 'mvn install' su tutti i progetti Maven selezionati.
 Per default i test sono disabilitati. Usa il flag --tests o -t per abilitarli.
 Il comando cerca il file pom.xml in ogni progetto selezionato ed esegue l'installazione.
+I progetti Gradle vengono compilati nello stesso ordine con i task configurati in gradle_tasks
+(default: clean build), usando il Gradle Wrapper se presente; i pacchetti npm e pnpm eseguono
+lo script configurato in npm_script (default: build).
+Con --jobs N i progetti indipendenti vengono compilati in parallelo (al massimo N alla volta):
+ogni progetto parte appena le sue dipendenze sono state installate con successo e
+i progetti che dipendono da un progetto fallito vengono saltati.
+Con --from e --upto vengono installati solo una parte dei progetti selezionati, senza
+modificare la selezione salvata: --from installa il progetto e tutti quelli che dipendono
+da esso (come -amd di Maven), --upto il progetto e tutte le sue dipendenze (come -am).
+Il piano e l'esito di ogni progetto vengono salvati nella directory di configurazione:
+con --resume l'ultima esecuzione riprende dal primo progetto fallito o non avviato,
+saltando quelli già installati, purché selezione e dipendenze non siano cambiate.
+Dopo ogni build riuscita viene registrato il fingerprint del progetto (commit HEAD, modifiche
+locali, build system e argomenti della build): con --changed vengono ricompilati solo i progetti
+il cui fingerprint è cambiato e quelli che dipendono da essi.
+La durata di ogni progetto e delle sue fasi viene registrata nello storico: prima di ogni
+esecuzione vengono mostrati la durata stimata e il percorso critico delle dipendenze
+(vedi 'projman mvn stats').
+Se un progetto fornisce il Maven Wrapper (mvnw) viene usato al posto di Maven dal PATH;
+nel profilo si può forzare Maven dal PATH (system_maven) o un'installazione specifica (maven_home).
+Ogni progetto viene compilato con il JDK configurato per il progetto (projects.<nome>.java_home)
+o con quello della versione dichiarata in .sdkmanrc, .java-version, .tool-versions o nel pom.xml
+(maven.compiler.release, java.version); in mancanza si usa java_home del profilo o l'ambiente corrente.
+Settings.xml, repository locale, modalità offline, profili Maven e proprietà -D configurati
+nel profilo possono essere sovrascritti con --settings, --local-repo, --offline, -P e -D.
+Con --output json sullo stdout vengono emessi solo eventi JSON, uno per riga (schema versionato):
+run_started, project_started, phase_started, test_results, project_finished e run_finished.
+I messaggi per l'utente vengono scritti su stderr e al primo errore l'esecuzione si interrompe:
+con --jobs non vengono avviati altri progetti e si attende la fine delle build già in corso.
 
 Esempi:
   projman mvn install         - Installa i progetti senza eseguire i test
-  projman mvn install --tests - Installa i progetti eseguendo i test`,
+  projman mvn install --tests - Installa i progetti eseguendo i test
+  projman mvn install -j 4    - Installa fino a 4 progetti indipendenti in parallelo
+  projman mvn install --from core - Reinstalla 'core' e i progetti che dipendono da esso
+  projman mvn install --upto web  - Installa 'web' e tutti i progetti da cui dipende
+  projman mvn install --resume    - Riprende l'ultima esecuzione dal punto di errore
+  projman mvn install --changed   - Ricompila solo i progetti modificati e i loro dipendenti
+  projman mvn install -P ci,!local --offline - Installa con i profili Maven indicati, senza rete
+  projman mvn install --output json > build.ndjson - Salva gli eventi della build in formato NDJSON`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Carica configurazione e seleziona progetti
-		cfg, _, err := cmdutil.LoadConfigAndSelectProjects()
+		jsonOutput, err := parseOutputFormat(outputFormat)
+		if err != nil {
+			pterm.Error.Println(err)
+			return
+		}
+		if jsonOutput {
+			// Lo stdout è riservato agli eventi JSON: i messaggi per l'utente vanno su stderr
+			pterm.SetDefaultOutput(os.Stderr)
+		}
+
+		// Carica configurazione e seleziona progetti; con --from/--upto/--resume e in modalità
+		// JSON si usa la selezione salvata senza chiederla né modificarla
+		var cfg *config.Config
+		if len(installFrom) > 0 || len(installUpTo) > 0 || resumeRun || jsonOutput {
+			cfg, err = config.LoadAndValidateConfig()
+		} else {
+			cfg, _, err = cmdutil.LoadConfigAndSelectProjects()
+		}
 		if err != nil {
 			return
 		}
 
-		// Determina quale profilo Maven usare (priorità: flag runtime > config > nessuno)
-		profileToUse := mavenProfile // Da flag --profile
-		if profileToUse == "" && cfg.MavenProfile != "" {
-			profileToUse = cfg.MavenProfile // Da configurazione
+		// Determina le opzioni Maven (priorità: flag runtime > config)
+		mavenOpts, err := resolveMavenOptions(cmd, cfg, &installMavenFlags)
+		if err != nil {
+			pterm.Error.Println("Opzioni Maven non valide:", err)
+			return
 		}
 
 		// Mostra informazioni sull'esecuzione
@@ -45,127 +114,204 @@ Esempi:
 			pterm.Info.Println("Esecuzione con test disabilitati (-DskipTests=true)")
 		}
 
-		// Mostra informazioni sul profilo Maven se presente
-		if profileToUse != "" {
-			pterm.Info.Printf("Profilo Maven: %s\n", profileToUse)
-		}
+		// Mostra le opzioni Maven attive (profili, settings, repository locale, offline)
+		mavenOpts.print()
 
-		// Costruisci il grafo delle dipendenze
-		spinner, _ := pterm.DefaultSpinner.Start("Analisi dipendenze Maven...")
+		// Verifica la Maven home configurata prima di avviare le build
+		if err := mavenLauncher(cfg).Validate(); err != nil {
+			pterm.Error.Println("Configurazione non valida:", err)
+			return
+		}
+		if cfg.MavenHome != "" {
+			pterm.Info.Printf("Maven home: %s\n", cfg.MavenHome)
+		}
 
-		dependencyGraph, err := maven.BuildDependencyGraph(cfg.SelectedProjects, cfg.RootOfProjects)
+		// Costruisci e ordina il grafo delle dipendenze
+		analysis, sortedProjects, err := analyzeProjects(cfg, installUpTo, installFrom)
 		if err != nil {
-			spinner.Fail("Errore durante l'analisi delle dipendenze:", err)
 			return
 		}
+		dependencyGraph := analysis.Graph
 
-		// Ordina i progetti topologicamente in base alle dipendenze
-		sortedProjects, err := maven.TopologicalSort(dependencyGraph)
+		fingerprints, err := loadFingerprints()
 		if err != nil {
-			spinner.Fail("Errore durante l'ordinamento dei progetti:", err)
-			pterm.Error.Println("Le dipendenze tra i progetti potrebbero contenere cicli")
+			pterm.Error.Println(err)
 			return
 		}
 
-		spinner.Success("Analisi dipendenze completata")
-
-		// Mostra l'ordine di installazione
-		pterm.Info.Println("\nOrdine di installazione (basato sulle dipendenze):")
-		for i, projectName := range sortedProjects {
-			deps := dependencyGraph[projectName]
-			if len(deps) > 0 {
-				pterm.Info.Printf("  %d. %s (dipende da: %v)\n", i+1, projectName, deps)
-			} else {
-				pterm.Info.Printf("  %d. %s (nessuna dipendenza interna)\n", i+1, projectName)
+		// Con --changed si ricompilano solo i progetti modificati e i loro dipendenti
+		if onlyChanged {
+			analysis, err = planChangedProjects(cfg, analysis, sortedProjects, fingerprints, mavenOpts)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			if len(analysis.Graph) == 0 {
+				pterm.Success.Println("Tutti i progetti sono aggiornati: nessun progetto da ricompilare")
+				return
+			}
+			dependencyGraph = analysis.Graph
+			if sortedProjects, err = analysis.TopologicalSort(cfg.BuildPriority); err != nil {
+				pterm.Error.Println(err)
+				return
 			}
 		}
-		pterm.Println()
-
-		// Contatori per statistiche finali
-		successCount := 0
-		failureCount := 0
-		skippedCount := 0
-
-		// Esegui mvn install per ogni progetto nell'ordine corretto
-		for i, projectName := range sortedProjects {
-			// Mostra un'intestazione per il progetto corrente
-			pterm.DefaultHeader.WithFullWidth().Printf("Progetto %d/%d: %s", i+1, len(sortedProjects), projectName)
-
-			// Costruisci il percorso del pom.xml
-			pomPath := filepath.Join(cfg.RootOfProjects, projectName, "pom.xml")
-
-			// Prepara gli argomenti per Maven
-			args := buildMavenArgs(pomPath, runTests, profileToUse)
-
-			// Esegui il comando Maven con il nuovo executor
-			mavenExec := executor.NewMavenExecutor(projectName, args)
-			if err := mavenExec.Run(); err != nil {
-				mavenExec.CurrentSpinner.Fail("  ", err.Error())
-				failureCount++
-
-				// Chiedi all'utente se vuole continuare
-				if !exec.WaitForUserInput(projectName) {
-					pterm.Warning.Println("Esecuzione interrotta dall'utente")
-					skippedCount = len(sortedProjects) - i - 1
-					break
-				}
-			} else {
-				successCount++
+
+		// Salva il piano dell'esecuzione o carica quello da riprendere
+		run, err := prepareRun(cfg.SelectedProjects, dependencyGraph, sortedProjects)
+		if err != nil {
+			pterm.Error.Println(err)
+			return
+		}
+		if resumeRun {
+			if run.Completed() {
+				pterm.Success.Printf("L'esecuzione %s è già stata completata: nessun progetto da installare\n", run.ID)
+				return
 			}
+			pterm.Info.Printf("Ripresa dell'esecuzione %s: %d progetti già installati verranno saltati\n",
+				run.ID, len(run.Succeeded()))
+		}
 
-			// Aggiungi una riga vuota tra i progetti (tranne dopo l'ultimo)
-			if i < len(sortedProjects)-1 {
-				pterm.Println()
+		buildHistory, err := loadHistory()
+		if err != nil {
+			pterm.Error.Println(err)
+			return
+		}
+		printEstimate(buildHistory, run, dependencyGraph, cfg.BuildPriority, jobs)
+
+		session := &buildSession{
+			cfg: cfg,
+			command: func(projectName string) projectBuild {
+				return installCommand(cfg, projectName, mavenOpts)
+			},
+			run:          run,
+			fingerprints: fingerprints,
+			history:      buildHistory,
+			jdks:         jdk.NewResolver(javaSettings(cfg)),
+			report:       newBuildReport(),
+		}
+		if jsonOutput {
+			session.events = events.NewEmitter(os.Stdout, run.ID)
+		}
+		session.events.RunStarted(sortedProjects, jobs)
+		startedAt := time.Now()
+
+		// Con più job i progetti vengono schedulati per livelli del grafo
+		var summary events.Summary
+		var builtProjects []string
+		if jobs > 1 {
+			var ok bool
+			if summary, builtProjects, ok = runParallel(session, dependencyGraph, jobs); !ok {
+				return
 			}
+		} else {
+			summary, builtProjects = runSequential(session, dependencyGraph, sortedProjects)
+		}
+
+		// Con i test abilitati mostra il report aggregato dei progetti compilati
+		if runTests {
+			printTestReport(cfg, run, builtProjects)
 		}
 
 		// Mostra il riepilogo finale
-		printInstallSummary(successCount, failureCount, skippedCount)
+		printBuildSummary(summary, session.report)
+		printResumeHint(run)
+		session.events.RunFinished(time.Since(startedAt), summary)
 	},
 }
 
-// buildMavenArgs costruisce gli argomenti per il comando Maven
-func buildMavenArgs(pomPath string, includeTests bool, profileToUse string) []string {
-	args := []string{"-B", "-f", pomPath, "clean", "install"}
+// installGoals sono i goal Maven eseguiti da mvn install
+var installGoals = []string{"clean", "install"}
+
+// installCommand restituisce il comando di mvn install per un progetto selezionato: clean install
+// per i progetti Maven, i task o lo script configurati per quelli Gradle e npm
+func installCommand(cfg *config.Config, projectName string, opts mavenOptions) projectBuild {
+	return projectCommand(cfg, projectName, installGoals, !runTests, opts)
+}
 
-	// Aggiunge il profilo Maven se specificato
-	if profileToUse != "" {
-		args = append(args, "-P", profileToUse)
+// parseOutputFormat valida il formato di output e indica se è richiesto l'output JSON
+func parseOutputFormat(format string) (bool, error) {
+	switch strings.ToLower(format) {
+	case outputText:
+		return false, nil
+	case outputJSON:
+		return true, nil
+	default:
+		return false, fmt.Errorf("formato di output '%s' non supportato (valori ammessi: %s, %s)", format, outputText, outputJSON)
 	}
+}
 
-	if !includeTests {
-		args = append(args, "-DskipTests=true")
+// phaseRules converte le fasi configurate nel profilo in regole per il riconoscimento delle fasi Maven
+func phaseRules(cfg *config.Config) []maven.PhaseRule {
+	rules := make([]maven.PhaseRule, len(cfg.MavenPhases))
+	for i, phase := range cfg.MavenPhases {
+		rules[i] = maven.PhaseRule{
+			Plugin: phase.Plugin,
+			Goal:   phase.Goal,
+			Name:   phase.Name,
+			Label:  phase.Label,
+		}
 	}
-	return args
+	return rules
+}
+
+// mavenLauncher restituisce la scelta dell'eseguibile Maven configurata nel profilo
+func mavenLauncher(cfg *config.Config) maven.Launcher {
+	return maven.Launcher{SystemOnly: cfg.SystemMaven, Home: cfg.MavenHome}
 }
 
-// printInstallSummary stampa un riepilogo delle operazioni di installazione
-func printInstallSummary(successCount, failureCount, skippedCount int) {
+// javaSettings restituisce la configurazione dei JDK del profilo
+func javaSettings(cfg *config.Config) jdk.Settings {
+	projectHomes := make(map[string]string, len(cfg.Projects))
+	for projectName, settings := range cfg.Projects {
+		if settings.JavaHome != "" {
+			projectHomes[projectName] = settings.JavaHome
+		}
+	}
+	return jdk.Settings{DefaultHome: cfg.JavaHome, ProjectHomes: projectHomes, Homes: cfg.JavaHomes}
+}
+
+// printBuildSummary stampa un riepilogo delle build dei progetti
+// seguito dai problemi rilevati nelle build fallite
+func printBuildSummary(summary events.Summary, report *buildReport) {
+	report.print()
+
 	pterm.Println()
 	pterm.DefaultSection.Println("Riepilogo Operazioni")
 
-	totalCount := successCount + failureCount + skippedCount
+	totalCount := summary.Succeeded + summary.Failed + summary.Skipped
 	pterm.Info.Printf("Progetti totali: %d\n", totalCount)
 
-	if successCount > 0 {
-		pterm.Success.Printf("Installazioni riuscite: %d\n", successCount)
+	if summary.Succeeded > 0 {
+		pterm.Success.Printf("Build riuscite: %d\n", summary.Succeeded)
+	}
+
+	if summary.Failed > 0 {
+		pterm.Error.Printf("Build fallite: %d\n", summary.Failed)
 	}
 
-	if failureCount > 0 {
-		pterm.Error.Printf("Installazioni fallite: %d\n", failureCount)
+	if summary.AlreadyInstalled > 0 {
+		pterm.Info.Printf("Già installati nell'esecuzione ripresa: %d\n", summary.AlreadyInstalled)
 	}
 
-	if skippedCount > 0 {
-		pterm.Warning.Printf("Installazioni saltate: %d\n", skippedCount)
+	if skipped := summary.Skipped - summary.AlreadyInstalled; skipped > 0 {
+		pterm.Warning.Printf("Build saltate: %d\n", skipped)
 	}
 
-	if failureCount == 0 && skippedCount == 0 {
-		pterm.Success.Println("Tutte le installazioni completate con successo!")
+	if summary.Failed == 0 && summary.Skipped == summary.AlreadyInstalled {
+		pterm.Success.Println("Tutte le build completate con successo!")
 	}
 }
 
 func init() {
 	MvnCmd.AddCommand(installCmd)
 	installCmd.Flags().BoolVarP(&runTests, "tests", "t", false, "Abilita l'esecuzione dei test durante l'installazione")
-	installCmd.Flags().StringVarP(&mavenProfile, "profile", "P", "", "Profilo Maven da usare (sovrascrive quello configurato)")
+	installMavenFlags.register(installCmd)
+	installCmd.Flags().IntVarP(&jobs, "jobs", "j", 1, "Numero massimo di progetti compilati in parallelo")
+	installCmd.Flags().StringSliceVar(&installFrom, "from", nil, "Installa solo il progetto e quelli che dipendono da esso (transitivamente)")
+	installCmd.Flags().StringSliceVar(&installUpTo, "upto", nil, "Installa solo il progetto e le sue dipendenze (transitive)")
+	installCmd.Flags().BoolVar(&resumeRun, "resume", false, "Riprende l'ultima esecuzione saltando i progetti già installati")
+	installCmd.Flags().BoolVar(&onlyChanged, "changed", false, "Ricompila solo i progetti modificati dall'ultima build riuscita e i loro dipendenti")
+	installCmd.MarkFlagsMutuallyExclusive("resume", "changed")
+	installCmd.Flags().StringVarP(&outputFormat, "output", "o", outputText, "Formato di output: text o json (eventi NDJSON su stdout)")
 }
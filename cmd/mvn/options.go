@@ -0,0 +1,163 @@
+package mvn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// mavenFlags sono le opzioni Maven che un comando permette di sovrascrivere a runtime
+type mavenFlags struct {
+	profiles        []string
+	settingsFile    string
+	localRepository string
+	offline         bool
+	properties      []string
+}
+
+// register aggiunge al comando i flag delle opzioni Maven
+func (f *mavenFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&f.profiles, "profile", "P", nil, "Profili Maven da usare, separati da virgola (es. a,b,!c; sovrascrive quelli configurati)")
+	cmd.Flags().StringVarP(&f.settingsFile, "settings", "s", "", "File settings.xml da usare (sovrascrive quello configurato)")
+	cmd.Flags().StringVar(&f.localRepository, "local-repo", "", "Repository locale Maven da usare (sovrascrive quello configurato)")
+	cmd.Flags().BoolVar(&f.offline, "offline", false, "Esegue Maven offline (--offline=false ignora la configurazione)")
+	cmd.Flags().StringArrayVarP(&f.properties, "define", "D", nil, "Proprietà Maven aggiuntiva nella forma chiave=valore (ripetibile)")
+}
+
+// mavenOptions sono le opzioni passate a Maven in ogni build, ottenute dalla configurazione
+// del profilo e dai flag runtime
+type mavenOptions struct {
+	profiles        []string          // -P a,b,!c
+	settingsFile    string            // -s settings.xml
+	localRepository string            // -Dmaven.repo.local=...
+	offline         bool              // -o
+	properties      map[string]string // -Dchiave=valore
+}
+
+// resolveMavenOptions unisce le opzioni Maven del profilo con i flag runtime (che hanno la precedenza).
+// Le proprietà indicate con -D si aggiungono a quelle configurate, sovrascrivendo quelle con lo stesso nome.
+// I percorsi vengono resi assoluti, perché Maven viene avviato nella directory di ogni progetto.
+func resolveMavenOptions(cmd *cobra.Command, cfg *config.Config, flags *mavenFlags) (mavenOptions, error) {
+	opts := mavenOptions{
+		profiles:        splitProfiles(cfg.MavenProfile),
+		settingsFile:    cfg.MavenSettings,
+		localRepository: cfg.LocalRepository,
+		offline:         cfg.Offline,
+		properties:      make(map[string]string, len(cfg.MavenProperties)+len(flags.properties)),
+	}
+	for name, value := range cfg.MavenProperties {
+		opts.properties[name] = value
+	}
+
+	if len(flags.profiles) > 0 {
+		opts.profiles = splitProfiles(strings.Join(flags.profiles, ","))
+	}
+	if flags.settingsFile != "" {
+		opts.settingsFile = flags.settingsFile
+	}
+	if flags.localRepository != "" {
+		opts.localRepository = flags.localRepository
+	}
+	if cmd.Flags().Changed("offline") {
+		opts.offline = flags.offline
+	}
+	for _, property := range flags.properties {
+		name, value, found := strings.Cut(property, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return mavenOptions{}, fmt.Errorf("proprietà '%s' non valida: usa la forma chiave=valore", property)
+		}
+		opts.properties[strings.TrimSpace(name)] = value
+	}
+
+	var err error
+	if opts.settingsFile != "" {
+		if opts.settingsFile, err = absolutePath(opts.settingsFile); err != nil {
+			return mavenOptions{}, err
+		}
+		if _, err := os.Stat(opts.settingsFile); err != nil {
+			return mavenOptions{}, fmt.Errorf("file settings.xml non accessibile: %w", err)
+		}
+	}
+	if opts.localRepository != "" {
+		if opts.localRepository, err = absolutePath(opts.localRepository); err != nil {
+			return mavenOptions{}, err
+		}
+	}
+	return opts, nil
+}
+
+// args restituisce gli argomenti Maven corrispondenti alle opzioni, in ordine deterministico
+func (o mavenOptions) args() []string {
+	args := make([]string, 0, 4+len(o.properties))
+	if o.settingsFile != "" {
+		args = append(args, "-s", o.settingsFile)
+	}
+	if o.offline {
+		args = append(args, "-o")
+	}
+	if len(o.profiles) > 0 {
+		args = append(args, "-P", strings.Join(o.profiles, ","))
+	}
+	if o.localRepository != "" {
+		args = append(args, "-Dmaven.repo.local="+o.localRepository)
+	}
+
+	names := make([]string, 0, len(o.properties))
+	for name := range o.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("-D%s=%s", name, o.properties[name]))
+	}
+	return args
+}
+
+// print mostra le opzioni Maven attive
+func (o mavenOptions) print() {
+	if len(o.profiles) > 0 {
+		pterm.Info.Printf("Profili Maven: %s\n", strings.Join(o.profiles, ","))
+	}
+	if o.settingsFile != "" {
+		pterm.Info.Printf("Settings Maven: %s\n", o.settingsFile)
+	}
+	if o.localRepository != "" {
+		pterm.Info.Printf("Repository locale: %s\n", o.localRepository)
+	}
+	if o.offline {
+		pterm.Info.Println("Modalità offline")
+	}
+}
+
+// splitProfiles divide un elenco di profili Maven separati da virgola, ignorando spazi e voci vuote
+func splitProfiles(profiles string) []string {
+	result := make([]string, 0)
+	for _, profile := range strings.Split(profiles, ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			result = append(result, profile)
+		}
+	}
+	return result
+}
+
+// absolutePath espande il prefisso ~ e rende assoluto il percorso indicato
+func absolutePath(path string) (string, error) {
+	if rest, found := strings.CutPrefix(path, "~"); found && (rest == "" || os.IsPathSeparator(rest[0])) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("impossibile espandere '%s': %w", path, err)
+		}
+		path = home + rest
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("percorso '%s' non valido: %w", path, err)
+	}
+	return absPath, nil
+}
@@ -0,0 +1,113 @@
+package mvn
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/events"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+)
+
+// runParallel esegue la build dei progetti del grafo con al massimo jobs build concorrenti.
+// Ogni progetto ha la propria riga di progresso; i progetti che dipendono da un progetto
+// fallito non vengono avviati; in modalità JSON, come nell'esecuzione sequenziale, dopo il primo errore
+// non viene avviato nessun altro progetto e si attende la fine delle build in corso. Gli esiti vengono registrati nello stato dell'esecuzione
+// e i progetti già installati in un'esecuzione ripresa non vengono ricompilati.
+// Restituisce il riepilogo degli esiti e i progetti compilati; false se la schedulazione è fallita.
+func runParallel(session *buildSession, dependencyGraph graph.DependencyGraph, jobs int) (events.Summary, []string, bool) {
+	cfg, run := session.cfg, session.run
+
+	levels, err := dependencyGraph.Levels()
+	if err != nil {
+		pterm.Error.Println("Errore durante il raggruppamento dei progetti:", err)
+		return events.Summary{}, nil, false
+	}
+
+	// Mostra i livelli di esecuzione (i progetti di uno stesso livello sono indipendenti)
+	pterm.Info.Printf("\nPiano di esecuzione parallela (%d job):\n", jobs)
+	for i, level := range levels {
+		pterm.Info.Printf("  Livello %d: %s\n", i+1, strings.Join(level, ", "))
+	}
+	pterm.Println()
+
+	// Progetti già installati nell'esecuzione ripresa, contati come saltati
+	alreadyInstalled := make(map[string]bool)
+	for _, projectName := range run.Succeeded() {
+		alreadyInstalled[projectName] = true
+	}
+
+	// Una riga di progresso per ogni progetto, creata prima dell'avvio del multi printer.
+	// In modalità JSON l'avanzamento delle build non viene mostrato.
+	multi := pterm.DefaultMultiPrinter
+	writers := make(map[string]io.Writer, len(dependencyGraph))
+	for _, level := range levels {
+		for _, projectName := range level {
+			if session.events != nil {
+				writers[projectName] = io.Discard
+			} else {
+				writers[projectName] = multi.NewWriter()
+			}
+		}
+	}
+	if session.events == nil {
+		_, _ = multi.Start()
+	}
+
+	statuses, err := dependencyGraph.Schedule(jobs, cfg.BuildPriority, func(projectName string) error {
+		if alreadyInstalled[projectName] {
+			_, _ = fmt.Fprintf(writers[projectName], "↷ [%s] già installato nell'esecuzione %s\n", projectName, run.ID)
+			session.events.ProjectSkipped(projectName, "già installato nell'esecuzione "+run.ID)
+			return nil
+		}
+		err := session.buildProject(projectName, writers[projectName])
+		if err != nil && session.events != nil {
+			return fmt.Errorf("%w: %w", graph.ErrStop, err)
+		}
+		return err
+	})
+
+	if session.events == nil {
+		_, _ = multi.Stop()
+	}
+
+	if err != nil {
+		pterm.Error.Println("Errore durante l'esecuzione parallela:", err)
+		return events.Summary{}, nil, false
+	}
+
+	// Conta gli esiti e segnala i progetti saltati
+	var summary events.Summary
+	builtProjects := make([]string, 0, len(dependencyGraph))
+	for _, level := range levels {
+		for _, projectName := range level {
+			switch statuses[projectName] {
+			case graph.StatusSucceeded:
+				if alreadyInstalled[projectName] {
+					summary.Skipped++
+					summary.AlreadyInstalled++
+				} else {
+					summary.Succeeded++
+					builtProjects = append(builtProjects, projectName)
+				}
+			case graph.StatusFailed:
+				summary.Failed++
+				builtProjects = append(builtProjects, projectName)
+			case graph.StatusSkipped:
+				summary.Skipped++
+				recordStatus(run, projectName, runstate.StatusSkipped)
+				session.events.ProjectSkipped(projectName, "una dipendenza è fallita")
+				pterm.Warning.Printf("⊘ %s saltato: una dipendenza è fallita\n", projectName)
+			case graph.StatusCancelled:
+				// Il progetto resta pending nello stato dell'esecuzione, così che --resume lo compili
+				summary.Skipped++
+				session.events.ProjectSkipped(projectName, "esecuzione interrotta dopo un errore")
+				pterm.Warning.Printf("⊘ %s saltato: esecuzione interrotta dopo un errore\n", projectName)
+			}
+		}
+	}
+
+	return summary, builtProjects, true
+}
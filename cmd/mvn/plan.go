@@ -0,0 +1,54 @@
+package mvn
+
+import (
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/cmdutil"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/pterm/pterm"
+)
+
+// analyzeProjects analizza le dipendenze dei progetti selezionati considerando solo i tipi di
+// dipendenza configurati e limita il grafo ai progetti richiesti con --upto e --from.
+// Restituisce l'analisi e l'ordine topologico dei progetti; gli errori vengono mostrati all'utente.
+func analyzeProjects(cfg *config.Config, upTo, from []string) (*buildsystem.DependencyAnalysis, []string, error) {
+	spinner, _ := pterm.DefaultSpinner.Start("Analisi dipendenze Maven...")
+
+	edgeKinds, err := buildsystem.ParseEdgeKinds(cfg.EdgeKinds)
+	if err != nil {
+		spinner.Fail("Configurazione non valida:", err)
+		return nil, nil, err
+	}
+
+	analysis, err := cmdutil.AnalyzeProjects(cfg)
+	if err != nil {
+		spinner.Fail("Errore durante l'analisi delle dipendenze:", err)
+		return nil, nil, err
+	}
+
+	// Considera solo i tipi di dipendenza configurati (es. esclude plugin ed estensioni)
+	analysis = analysis.Filter(edgeKinds)
+
+	// Limita l'esecuzione al sottografo richiesto con --from/--upto
+	analysis, err = analysis.Restrict(upTo, from)
+	if err != nil {
+		spinner.Fail("Progetti non validi:", err)
+		return nil, nil, err
+	}
+
+	// Ordina i progetti topologicamente in base alle dipendenze
+	sortedProjects, err := analysis.TopologicalSort(cfg.BuildPriority)
+	if err != nil {
+		spinner.Fail("Errore durante l'ordinamento dei progetti")
+		pterm.Error.Println(err)
+		return nil, nil, err
+	}
+
+	spinner.Success("Analisi dipendenze completata")
+
+	// Segnala i valori dei pom.xml che non è stato possibile risolvere
+	for _, warning := range analysis.Warnings {
+		pterm.Warning.Println(warning)
+	}
+
+	return analysis, sortedProjects, nil
+}
@@ -0,0 +1,135 @@
+package mvn
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+	"github.com/pterm/pterm"
+)
+
+// maxDiagnosticsPerKind limita i problemi mostrati per categoria nel riepilogo di ogni progetto
+const maxDiagnosticsPerKind = 10
+
+// diagnosticSections definisce l'ordine e il titolo delle categorie nel riepilogo
+var diagnosticSections = []struct {
+	kind  executor.DiagnosticKind
+	title string
+}{
+	{executor.DiagnosticDependency, "Dipendenze non risolte"},
+	{executor.DiagnosticEnforcer, "Regole enforcer violate"},
+	{executor.DiagnosticCompilation, "Errori di compilazione"},
+	{executor.DiagnosticTest, "Test falliti"},
+}
+
+// projectFailure raccoglie i problemi rilevati nella build fallita di un progetto
+type projectFailure struct {
+	diagnostics []executor.Diagnostic
+	logPath     string
+}
+
+// buildReport raccoglie i problemi dei progetti falliti e gli avvisi emersi durante l'esecuzione.
+// È sicuro per l'uso concorrente.
+type buildReport struct {
+	mu       sync.Mutex
+	failures map[string]projectFailure
+	warnings map[string][]string // Avvisi non mostrati durante le build (modalità compatta)
+}
+
+// newBuildReport crea un report vuoto
+func newBuildReport() *buildReport {
+	return &buildReport{failures: make(map[string]projectFailure), warnings: make(map[string][]string)}
+}
+
+// addWarnings registra gli avvisi di un progetto da mostrare nel riepilogo
+func (r *buildReport) addWarnings(projectName string, warnings ...string) {
+	if len(warnings) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnings[projectName] = append(r.warnings[projectName], warnings...)
+}
+
+// addFailure registra i problemi riconosciuti nella build fallita di un progetto
+func (r *buildReport) addFailure(projectName string, buildExec *executor.Executor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[projectName] = projectFailure{
+		diagnostics: buildExec.Diagnostics(),
+		logPath:     buildExec.LogPath(),
+	}
+}
+
+// print stampa gli avvisi raccolti e, per ogni progetto fallito, i problemi rilevati raggruppati per categoria
+func (r *buildReport) print() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.printWarnings()
+	if len(r.failures) == 0 {
+		return
+	}
+
+	projects := make([]string, 0, len(r.failures))
+	for projectName := range r.failures {
+		projects = append(projects, projectName)
+	}
+	sort.Strings(projects)
+
+	pterm.Println()
+	pterm.DefaultSection.Println("Problemi Rilevati")
+	for _, projectName := range projects {
+		failure := r.failures[projectName]
+		pterm.Error.Println(projectName)
+
+		if len(failure.diagnostics) == 0 {
+			pterm.Printf("    Nessun errore riconosciuto nell'output della build\n")
+		}
+		for _, section := range diagnosticSections {
+			items := make([]executor.Diagnostic, 0)
+			for _, diagnostic := range failure.diagnostics {
+				if diagnostic.Kind == section.kind {
+					items = append(items, diagnostic)
+				}
+			}
+			if len(items) == 0 {
+				continue
+			}
+
+			pterm.Printf("    %s (%d):\n", section.title, len(items))
+			for i, diagnostic := range items {
+				if i == maxDiagnosticsPerKind {
+					pterm.Printf("      ... e altri %d\n", len(items)-maxDiagnosticsPerKind)
+					break
+				}
+				pterm.Printf("      • %s\n", diagnostic)
+			}
+		}
+
+		if failure.logPath != "" {
+			pterm.Printf("    Log completo: %s\n", failure.logPath)
+		}
+	}
+}
+
+// printWarnings stampa gli avvisi raccolti per ogni progetto; il chiamante deve possedere il lock
+func (r *buildReport) printWarnings() {
+	if len(r.warnings) == 0 {
+		return
+	}
+
+	projects := make([]string, 0, len(r.warnings))
+	for projectName := range r.warnings {
+		projects = append(projects, projectName)
+	}
+	sort.Strings(projects)
+
+	pterm.Println()
+	pterm.DefaultSection.Println("Avvisi")
+	for _, projectName := range projects {
+		for _, warning := range r.warnings[projectName] {
+			pterm.Warning.Printf("%s: %s\n", projectName, warning)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package mvn
+
+import (
+	"fmt"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+)
+
+// prepareRun crea e salva lo stato di una nuova esecuzione oppure, con --resume,
+// carica quello dell'ultima esecuzione verificando che selezione e grafo non siano cambiati
+func prepareRun(selection []string, dependencyGraph graph.DependencyGraph, plan []string) (*runstate.Run, error) {
+	dataDir, err := config.ProfileDataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	if !resumeRun {
+		run := runstate.New(dataDir, selection, dependencyGraph, plan)
+		if err := run.Save(); err != nil {
+			return nil, err
+		}
+		return run, nil
+	}
+
+	run, err := runstate.Load(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := run.CheckResumable(selection, dependencyGraph); err != nil {
+		return nil, fmt.Errorf("impossibile riprendere l'esecuzione: %w", err)
+	}
+	if err := run.Reset(); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// recordStatus registra l'esito di un progetto nello stato dell'esecuzione,
+// segnalando senza interrompere l'installazione eventuali errori di salvataggio
+func recordStatus(run *runstate.Run, projectName string, status runstate.Status) {
+	if err := run.SetStatus(projectName, status); err != nil {
+		pterm.Warning.Println("Impossibile aggiornare lo stato dell'esecuzione:", err)
+	}
+}
+
+// printResumeHint suggerisce come riprendere l'esecuzione se non tutti i progetti sono stati installati
+func printResumeHint(run *runstate.Run) {
+	if !run.Completed() {
+		pterm.Info.Println("Usa 'projman mvn install --resume' per riprendere dal primo progetto non installato")
+	}
+}
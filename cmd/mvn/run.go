@@ -0,0 +1,150 @@
+package mvn
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/cmdutil"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/events"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/jdk"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var runMavenFlags mavenFlags
+var runJobs int
+var runUnordered bool
+var runFrom []string
+var runUpTo []string
+
+// runCmd rappresenta il comando per eseguire goal Maven arbitrari sui progetti selezionati
+var runCmd = &cobra.Command{
+	Use:   "run [flag] -- <goal e opzioni Maven>",
+	Short: "Esegue goal Maven arbitrari sui progetti selezionati",
+	Long: `Esegue i goal e le opzioni Maven indicati dopo '--' su tutti i progetti selezionati,
+con lo stesso ordinamento, la stessa gestione degli errori e lo stesso riepilogo di 'mvn install'.
+Gli argomenti dopo '--' vengono passati a Maven così come sono, dopo '-B -f <pom.xml>'.
+I progetti Gradle e npm vengono esclusi.
+
+Per default i progetti vengono elaborati nell'ordine delle dipendenze; con --jobs N i progetti
+indipendenti vengono elaborati in parallelo. Se i goal non dipendono dall'ordine (es. analisi
+o formattazione del codice) --unordered ignora le dipendenze ed esegue tutti i progetti in parallelo,
+per default con un job per CPU.
+
+Esempi:
+  projman mvn run -- verify                              - Esegue verify nell'ordine delle dipendenze
+  projman mvn run -j 4 -- verify -DskipITs               - Esegue verify con 4 build in parallelo
+  projman mvn run --unordered -- spotless:apply          - Formatta il codice di tutti i progetti in parallelo
+  projman mvn run --unordered -- dependency:tree         - Mostra l'albero delle dipendenze di ogni progetto
+  projman mvn run --upto web -- versions:display-dependency-updates - Aggiornamenti di 'web' e delle sue dipendenze`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		goals := args
+
+		// Con --from/--upto si usa la selezione salvata senza chiederla né modificarla
+		var cfg *config.Config
+		var err error
+		if len(runFrom) > 0 || len(runUpTo) > 0 {
+			cfg, err = config.LoadAndValidateConfig()
+		} else {
+			cfg, _, err = cmdutil.LoadConfigAndSelectProjects()
+		}
+		if err != nil {
+			return
+		}
+
+		mavenOpts, err := resolveMavenOptions(cmd, cfg, &runMavenFlags)
+		if err != nil {
+			pterm.Error.Println("Opzioni Maven non valide:", err)
+			return
+		}
+		pterm.Info.Printf("Goal Maven: %s\n", strings.Join(goals, " "))
+		mavenOpts.print()
+
+		if err := mavenLauncher(cfg).Validate(); err != nil {
+			pterm.Error.Println("Configurazione non valida:", err)
+			return
+		}
+
+		analysis, sortedProjects, err := analyzeProjects(cfg, runUpTo, runFrom)
+		if err != nil {
+			return
+		}
+		dependencyGraph := analysis.Graph
+
+		// I goal Maven non si applicano ai progetti Gradle e npm, che vengono esclusi
+		if mavenProjects, otherProjects := splitByBuildSystem(cfg, sortedProjects); len(otherProjects) > 0 {
+			pterm.Info.Printf("Progetti non Maven esclusi: %s\n", strings.Join(otherProjects, ", "))
+			dependencyGraph = dependencyGraph.Subgraph(mavenProjects)
+			sortedProjects = mavenProjects
+			if len(sortedProjects) == 0 {
+				pterm.Warning.Println("Nessun progetto Maven da elaborare")
+				return
+			}
+		}
+
+		// Se l'ordine è indifferente tutti i progetti sono indipendenti
+		jobsToUse := runJobs
+		if runUnordered {
+			dependencyGraph = unorderedGraph(sortedProjects)
+			if sortedProjects, err = dependencyGraph.TopologicalSortWithPriority(cfg.BuildPriority); err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			if !cmd.Flags().Changed("jobs") {
+				jobsToUse = runtime.NumCPU()
+			}
+			pterm.Info.Println("Ordine dei progetti indifferente: le dipendenze vengono ignorate")
+		}
+
+		dataDir, err := config.ProfileDataDir()
+		if err != nil {
+			pterm.Error.Println(err)
+			return
+		}
+
+		// L'esecuzione non può essere ripresa con --resume, ma i log dei progetti vengono conservati
+		session := &buildSession{
+			cfg: cfg,
+			command: func(projectName string) projectBuild {
+				return projectCommand(cfg, projectName, goals, false, mavenOpts)
+			},
+			run:    runstate.NewTransient(dataDir, cfg.SelectedProjects, dependencyGraph, sortedProjects),
+			jdks:   jdk.NewResolver(javaSettings(cfg)),
+			report: newBuildReport(),
+		}
+
+		var summary events.Summary
+		if jobsToUse > 1 {
+			var ok bool
+			if summary, _, ok = runParallel(session, dependencyGraph, jobsToUse); !ok {
+				return
+			}
+		} else {
+			summary, _ = runSequential(session, dependencyGraph, sortedProjects)
+		}
+
+		printBuildSummary(summary, session.report)
+	},
+}
+
+// unorderedGraph restituisce un grafo con i progetti indicati e nessuna dipendenza tra loro
+func unorderedGraph(projects []string) graph.DependencyGraph {
+	unordered := graph.NewDependencyGraph()
+	for _, projectName := range projects {
+		unordered.AddNode(projectName, nil)
+	}
+	return unordered
+}
+
+func init() {
+	MvnCmd.AddCommand(runCmd)
+	runMavenFlags.register(runCmd)
+	runCmd.Flags().IntVarP(&runJobs, "jobs", "j", 1, "Numero massimo di progetti elaborati in parallelo")
+	runCmd.Flags().BoolVar(&runUnordered, "unordered", false, "I goal non dipendono dall'ordine: ignora le dipendenze ed esegue in parallelo")
+	runCmd.Flags().StringSliceVar(&runFrom, "from", nil, "Esegue solo sul progetto e su quelli che dipendono da esso (transitivamente)")
+	runCmd.Flags().StringSliceVar(&runUpTo, "upto", nil, "Esegue solo sul progetto e sulle sue dipendenze (transitive)")
+}
@@ -0,0 +1,94 @@
+package mvn
+
+import (
+	"io"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/events"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/exec"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+)
+
+// runSequential esegue la build dei progetti uno alla volta nell'ordine indicato.
+// Dopo un errore chiede all'utente se continuare; in modalità JSON l'esecuzione si interrompe.
+// I progetti già installati in un'esecuzione ripresa vengono saltati.
+// Restituisce il riepilogo degli esiti e i progetti compilati.
+func runSequential(session *buildSession, dependencyGraph graph.DependencyGraph, sortedProjects []string) (events.Summary, []string) {
+	run := session.run
+	jsonOutput := session.events != nil
+
+	// Mostra l'ordine di esecuzione
+	pterm.Info.Println("\nOrdine di esecuzione (basato sulle dipendenze):")
+	for i, projectName := range sortedProjects {
+		deps := dependencyGraph[projectName]
+		if len(deps) > 0 {
+			pterm.Info.Printf("  %d. %s (dipende da: %v)\n", i+1, projectName, deps)
+		} else {
+			pterm.Info.Printf("  %d. %s (nessuna dipendenza interna)\n", i+1, projectName)
+		}
+	}
+	pterm.Println()
+
+	// In modalità JSON l'avanzamento delle build non viene mostrato
+	var progressWriter io.Writer
+	if jsonOutput {
+		progressWriter = io.Discard
+	}
+
+	var summary events.Summary
+	builtProjects := make([]string, 0, len(sortedProjects))
+
+	// skipInstalled conta e segnala un progetto già installato nell'esecuzione ripresa
+	skipInstalled := func(projectName string) {
+		pterm.Info.Printf("↷ %s già installato nell'esecuzione %s\n", projectName, run.ID)
+		session.events.ProjectSkipped(projectName, "già installato nell'esecuzione "+run.ID)
+		summary.Skipped++
+		summary.AlreadyInstalled++
+	}
+
+	// Esegui la build di ogni progetto nell'ordine corretto
+	for i, projectName := range sortedProjects {
+		// Salta i progetti già installati nell'esecuzione ripresa
+		if run.Status(projectName) == runstate.StatusSucceeded {
+			skipInstalled(projectName)
+			continue
+		}
+
+		// Mostra un'intestazione per il progetto corrente
+		pterm.DefaultHeader.WithFullWidth().Printf("Progetto %d/%d: %s", i+1, len(sortedProjects), projectName)
+
+		builtProjects = append(builtProjects, projectName)
+		if err := session.buildProject(projectName, progressWriter); err != nil {
+			summary.Failed++
+
+			// Chiedi all'utente se vuole continuare; in modalità JSON l'esecuzione si interrompe
+			stop := jsonOutput
+			if !stop && !exec.WaitForUserInput(projectName) {
+				pterm.Warning.Println("Esecuzione interrotta dall'utente")
+				stop = true
+			}
+			if stop {
+				// I progetti già installati nell'esecuzione ripresa vengono contati come se il ciclo li avesse raggiunti
+				for _, remaining := range sortedProjects[i+1:] {
+					if run.Status(remaining) == runstate.StatusSucceeded {
+						skipInstalled(remaining)
+						continue
+					}
+					session.events.ProjectSkipped(remaining, "esecuzione interrotta dopo l'errore di "+projectName)
+					summary.Skipped++
+				}
+				break
+			}
+		} else {
+			summary.Succeeded++
+		}
+
+		// Aggiungi una riga vuota tra i progetti (tranne dopo l'ultimo)
+		if i < len(sortedProjects)-1 {
+			pterm.Println()
+		}
+	}
+
+	return summary, builtProjects
+}
@@ -0,0 +1,131 @@
+package mvn
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/events"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/fingerprint"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/history"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/jdk"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+)
+
+// buildSession raccoglie lo stato condiviso dalle build dei progetti di un'esecuzione
+type buildSession struct {
+	cfg          *config.Config
+	command      func(projectName string) projectBuild // Build system e comando della build di ogni progetto
+	run          *runstate.Run
+	fingerprints *fingerprint.Store // nil se le build non aggiornano i fingerprint (solo mvn install li registra)
+	history      *history.Store     // nil se le durate non vengono registrate
+	jdks         *jdk.Resolver
+	report       *buildReport
+	events       *events.Emitter // nil se l'output non è JSON
+}
+
+// buildProject esegue la build di un progetto e ne registra esito, durata, fingerprint e problemi.
+// Con writer non nil l'avanzamento viene mostrato su un'unica riga (modalità compatta).
+func (s *buildSession) buildProject(projectName string, writer io.Writer) error {
+	// Prepara il comando di build e il fingerprint da registrare in caso di successo
+	build := s.command(projectName)
+	recordFingerprint := captureFingerprint(s.fingerprints, s.cfg, projectName, build)
+
+	projectDir := s.cfg.ProjectDir(projectName)
+
+	buildExec := executor.NewExecutor(projectName, build.command.Executable, build.command.Args, build.system.OutputParser()).
+		WithWorkDir(projectDir).
+		WithEnv(build.command.Env).
+		WithLogFile(s.run.LogPath(projectName))
+
+	// Sceglie il JDK del progetto; se la versione richiesta non è installata la build usa il JDK di ripiego
+	if build.system.UsesJDK() {
+		javaSelection, err := s.jdks.Resolve(projectName, projectDir)
+		if err != nil {
+			s.warn(projectName, writer, err.Error())
+		}
+		if writer == nil && javaSelection.Home != "" {
+			pterm.Info.Printf("JAVA_HOME: %s (%s)\n", javaSelection.Home, javaSelection.Source)
+		}
+		buildExec.WithJavaHome(javaSelection.Home)
+	}
+	if writer != nil {
+		buildExec.WithWriter(writer)
+	}
+	if s.events != nil {
+		buildExec.WithListener(projectEvents{emitter: s.events, project: projectName})
+	}
+
+	s.events.ProjectStarted(projectName, build.command.Args)
+	start := time.Now()
+	err := buildExec.Run()
+	s.events.ProjectFinished(projectName, time.Since(start), err, diagnosticMessages(buildExec), buildExec.LogPath())
+	if writer != nil {
+		// In modalità compatta gli avvisi restano sulla riga del progetto: vengono ripetuti nel riepilogo
+		s.report.addWarnings(projectName, buildExec.Warnings()...)
+	}
+	recordHistory(s.history, s.run, projectName, build.system.Name(), start, buildExec, err)
+
+	if err != nil {
+		buildExec.Fail(err)
+		recordStatus(s.run, projectName, runstate.StatusFailed)
+		s.report.addFailure(projectName, buildExec)
+		return err
+	}
+
+	recordStatus(s.run, projectName, runstate.StatusSucceeded)
+	recordFingerprint()
+	return nil
+}
+
+// warn mostra un avviso sulla build di un progetto. In modalità compatta viene scritto sulla riga
+// del progetto, per non scrivere nell'area delle righe di progresso, e ripetuto nel riepilogo.
+func (s *buildSession) warn(projectName string, writer io.Writer, message string) {
+	if writer == nil {
+		pterm.Warning.Printf("%s: %s\n", projectName, message)
+		return
+	}
+	_, _ = fmt.Fprintf(writer, "[%s] ⚠ %s\n", projectName, message)
+	s.report.addWarnings(projectName, message)
+}
+
+// diagnosticMessages restituisce i problemi riconosciuti nella build in forma testuale
+func diagnosticMessages(buildExec *executor.Executor) []string {
+	diagnostics := buildExec.Diagnostics()
+	messages := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		messages[i] = diagnostic.String()
+	}
+	return messages
+}
+
+// projectEvents inoltra all'emitter JSON le fasi e i risultati dei test di un progetto
+type projectEvents struct {
+	emitter *events.Emitter
+	project string
+}
+
+// PhaseStarted implementa executor.BuildListener
+func (p projectEvents) PhaseStarted(phase executor.Phase) {
+	p.emitter.PhaseStarted(p.project, events.Phase{
+		Name:   phase.Name,
+		Label:  phase.Description,
+		Plugin: phase.Plugin,
+		Goal:   phase.Goal,
+		Module: phase.Module,
+	})
+}
+
+// TestResults implementa executor.BuildListener
+func (p projectEvents) TestResults(results executor.TestResults) {
+	p.emitter.TestResults(p.project, events.TestResults{
+		Suite:    results.Suite,
+		Run:      results.Run,
+		Failures: results.Failures,
+		Errors:   results.Errors,
+		Skipped:  results.Skipped,
+	})
+}
@@ -0,0 +1,106 @@
+package mvn
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/history"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// stableTrendThreshold è la variazione percentuale sotto la quale la durata è considerata stabile
+const stableTrendThreshold = 5.0
+
+var statsLast int
+var statsTop int
+
+// statsCmd rappresenta il comando per consultare le statistiche delle build
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Mostra le statistiche sulle durate delle build Maven",
+	Long: `Mostra le statistiche calcolate dallo storico delle build di 'projman mvn install'
+del profilo corrente: durata media, ultima, minima e massima di ogni progetto,
+il trend (le build più recenti confrontate con le precedenti), i moduli e le fasi più lente.
+Le durate considerano solo le build riuscite; quelle fallite nella stessa finestra sono solo conteggiate.
+
+Esempi:
+  projman mvn stats            - Statistiche sulle ultime 10 build di ogni progetto
+  projman mvn stats --last 30  - Statistiche sulle ultime 30 build di ogni progetto
+  projman mvn stats --top 10   - Mostra i 10 moduli e le 10 fasi più lente`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadHistory()
+		if err != nil {
+			pterm.Error.Println("Errore nel caricamento dello storico:", err)
+			return err
+		}
+
+		stats := store.Stats(statsLast)
+		if len(stats.Projects) == 0 {
+			pterm.Info.Println("Nessuna build registrata: esegui prima 'projman mvn install'")
+			return nil
+		}
+
+		pterm.DefaultSection.Println("Durata delle build")
+		tableData := pterm.TableData{{"Progetto", "Build", "Fallite", "Media", "Ultima", "Min", "Max", "Trend"}}
+		for _, project := range stats.Projects {
+			row := []string{project.Project, strconv.Itoa(project.Builds), strconv.Itoa(project.Failures), "-", "-", "-", "-", "-"}
+			if project.Builds > project.Failures {
+				row[3] = formatDuration(project.Average)
+				row[4] = formatDuration(project.Last)
+				row[5] = formatDuration(project.Min)
+				row[6] = formatDuration(project.Max)
+				row[7] = formatTrend(project)
+			}
+			tableData = append(tableData, row)
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+
+		if len(stats.Modules) > 0 {
+			pterm.DefaultSection.Println("Moduli più lenti")
+			for _, module := range limitTop(stats.Modules, statsTop) {
+				name := module.Project
+				if module.Module != "" && module.Module != module.Project {
+					name = fmt.Sprintf("%s / %s", module.Project, module.Module)
+				}
+				pterm.Printf("  %8s  %s (%d build)\n", formatDuration(module.Average), name, module.Builds)
+			}
+		}
+
+		if len(stats.Phases) > 0 {
+			pterm.DefaultSection.Println("Fasi più lente")
+			for _, phase := range limitTop(stats.Phases, statsTop) {
+				pterm.Printf("  %8s  %s (%d build)\n", formatDuration(phase.Average), phase.Name, phase.Builds)
+			}
+		}
+		return nil
+	},
+}
+
+// formatTrend descrive la variazione della durata delle build recenti di un progetto
+func formatTrend(project history.ProjectStats) string {
+	switch {
+	case !project.HasTrend:
+		return "-"
+	case project.Trend >= stableTrendThreshold:
+		return pterm.Red(fmt.Sprintf("↑ +%.0f%%", project.Trend))
+	case project.Trend <= -stableTrendThreshold:
+		return pterm.Green(fmt.Sprintf("↓ %.0f%%", project.Trend))
+	default:
+		return "= stabile"
+	}
+}
+
+// limitTop restituisce i primi n elementi (tutti se n <= 0)
+func limitTop[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+func init() {
+	MvnCmd.AddCommand(statsCmd)
+	statsCmd.Flags().IntVar(&statsLast, "last", 10, "Numero di build recenti di ogni progetto da considerare (0 = tutte)")
+	statsCmd.Flags().IntVar(&statsTop, "top", 5, "Numero di moduli e fasi più lente da mostrare")
+}
@@ -0,0 +1,106 @@
+package mvn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven/testreport"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/runstate"
+	"github.com/pterm/pterm"
+)
+
+// Parametri del report dei test mostrato a terminale
+const (
+	slowestTestsShown    = 5 // Test più lenti mostrati
+	stackTraceLinesShown = 5 // Righe di stack trace mostrate per ogni test fallito
+)
+
+// Nomi dei file del report dei test salvati nella directory dell'esecuzione
+const (
+	junitReportFileName = "test-report.xml"
+	htmlReportFileName  = "test-report.html"
+)
+
+// printTestReport legge i report di Surefire e Failsafe dei progetti compilati con i test,
+// mostra un riepilogo aggregato e salva il report JUnit unificato e la pagina HTML
+// nella directory dell'esecuzione
+func printTestReport(cfg *config.Config, run *runstate.Run, projects []string) {
+	report := &testreport.Report{}
+	for _, projectName := range projects {
+		if err := report.AddProject(projectName, cfg.ProjectDir(projectName)); err != nil {
+			pterm.Warning.Println(err)
+		}
+	}
+	if report.Empty() {
+		return
+	}
+
+	pterm.Println()
+	pterm.DefaultSection.Println("Report dei Test")
+
+	tableData := pterm.TableData{{"Progetto", "Test", "Superati", "Falliti", "Errori", "Saltati", "Durata"}}
+	for _, project := range report.Projects {
+		tableData = append(tableData, totalsRow(project.Project, project.Totals()))
+	}
+	tableData = append(tableData, totalsRow("Totale", report.Totals()))
+	_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+
+	pterm.Println()
+	pterm.Info.Println("Test più lenti:")
+	for _, test := range report.Slowest(slowestTestsShown) {
+		pterm.Printf("    %6.2fs  %s  %s\n", test.Duration.Seconds(), test.Project, test.FullName())
+	}
+
+	if failures := report.Failures(); len(failures) > 0 {
+		pterm.Println()
+		pterm.Error.Printf("Test falliti (%d):\n", len(failures))
+		for _, test := range failures {
+			pterm.Printf("    %s  %s: %s\n", test.Project, test.FullName(), test.Message)
+			lines := strings.Split(test.StackTrace, "\n")
+			if len(lines) > stackTraceLinesShown {
+				lines = append(lines[:stackTraceLinesShown], "...")
+			}
+			for _, line := range lines {
+				if line = strings.TrimSpace(line); line != "" {
+					pterm.FgGray.Printf("        %s\n", line)
+				}
+			}
+		}
+	}
+
+	reportDir := run.LogDir()
+	if err := os.MkdirAll(reportDir, config.ConfigDirPermissions); err != nil {
+		pterm.Warning.Println("Impossibile creare la directory del report dei test:", err)
+		return
+	}
+
+	junitPath := filepath.Join(reportDir, junitReportFileName)
+	if err := report.WriteJUnit(junitPath); err != nil {
+		pterm.Warning.Println(err)
+	} else {
+		pterm.Info.Printf("Report JUnit: %s\n", junitPath)
+	}
+
+	htmlPath := filepath.Join(reportDir, htmlReportFileName)
+	if err := report.WriteHTML(htmlPath); err != nil {
+		pterm.Warning.Println(err)
+	} else {
+		pterm.Info.Printf("Report HTML: %s\n", htmlPath)
+	}
+}
+
+// totalsRow formatta i totali di un progetto come riga della tabella
+func totalsRow(name string, totals testreport.Totals) []string {
+	return []string{
+		name,
+		fmt.Sprint(totals.Tests),
+		fmt.Sprint(totals.Passed),
+		fmt.Sprint(totals.Failed),
+		fmt.Sprint(totals.Errors),
+		fmt.Sprint(totals.Skipped),
+		fmt.Sprintf("%.1fs", totals.Duration.Seconds()),
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var clearPriority bool
+
+// priorityCmd rappresenta il comando priority per gestire la priorità di build del profilo attivo
+var priorityCmd = &cobra.Command{
+	Use:   "priority [progetto...]",
+	Short: "Imposta i progetti da elaborare per primi",
+	Long: `Imposta la lista di priorità del profilo attivo.
+Quando più progetti possono essere elaborati in qualsiasi ordine (nessuna dipendenza tra loro),
+vengono scelti prima quelli in lista, nell'ordine indicato, e poi gli altri in ordine alfabetico.
+Le dipendenze vengono sempre rispettate.
+
+Senza argomenti mostra la lista corrente.
+
+Esempi:
+  projman priority                 - Mostra la lista di priorità
+  projman priority core-lib api    - Imposta la lista di priorità
+  projman priority --clear         - Rimuove la lista di priorità`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadSettings()
+		if err != nil {
+			pterm.Error.Println("Errore nel caricamento della configurazione:", err)
+			return err
+		}
+
+		// Senza argomenti mostra la priorità corrente
+		if len(args) == 0 && !clearPriority {
+			if len(cfg.BuildPriority) == 0 {
+				pterm.Info.Println("Nessuna priorità configurata: i progetti indipendenti sono ordinati alfabeticamente")
+				return nil
+			}
+			pterm.Info.Printf("Priorità: %s\n", strings.Join(cfg.BuildPriority, ", "))
+			return nil
+		}
+
+		if clearPriority {
+			cfg.BuildPriority = nil
+		} else {
+			cfg.BuildPriority = args
+		}
+
+		if err := config.SaveSettings(cfg); err != nil {
+			pterm.Error.Println("Errore nel salvataggio della configurazione:", err)
+			return err
+		}
+
+		if clearPriority {
+			pterm.Success.Println("Priorità rimossa")
+		} else {
+			pterm.Success.Printf("Priorità impostata: %s\n", strings.Join(cfg.BuildPriority, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(priorityCmd)
+	priorityCmd.Flags().BoolVar(&clearPriority, "clear", false, "Rimuove la lista di priorità")
+}
@@ -0,0 +1,220 @@
+package buildsystem
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+)
+
+// EdgeOrigin descrive la dichiarazione che ha generato un arco del grafo delle dipendenze
+type EdgeOrigin struct {
+	Source     string         // File che dichiara la dipendenza (es. pom.xml, build.gradle, package.json)
+	Identifier string         // Identificatore dell'artifact richiesto (es. groupId:artifactId)
+	Kind       DependencyKind // Tipo di relazione (dipendenza, parent, BOM, plugin, estensione)
+}
+
+// DefaultEdgeKinds sono i tipi di arco considerati per l'ordinamento se non configurati diversamente.
+// Plugin ed estensioni di build sono opzionali perché raramente sono prodotti dagli stessi progetti.
+var DefaultEdgeKinds = []DependencyKind{
+	KindDependency,
+	KindParent,
+	KindImport,
+}
+
+// DependencyAnalysis contiene il grafo delle dipendenze tra i progetti selezionati
+// e, per ogni arco, le dichiarazioni nei file di build che lo hanno generato.
+// Ogni arco è etichettato con i tipi di dipendenza delle sue origini (vedi EdgeKinds).
+type DependencyAnalysis struct {
+	Graph    graph.DependencyGraph
+	Origins  map[graph.Edge][]EdgeOrigin
+	Warnings []string // Avvisi emersi durante l'analisi dei file di build (es. proprietà non risolte)
+}
+
+// AnalyzeOptions configura l'analisi dei progetti
+type AnalyzeOptions struct {
+	ProjectDir func(projectName string) string // Directory di ogni progetto
+	Systems    []BuildSystem                   // Build system provati in ordine per riconoscere i progetti
+	Provides   map[string][]string             // Artifact prodotti da ogni progetto ma non dichiarati nei file di build (es. client generati)
+}
+
+// ProjectSetAware è implementata dai build system che, prima dell'analisi, devono conoscere
+// tutti i progetti analizzati (es. Maven, per risolvere i parent che si trovano in un altro progetto)
+type ProjectSetAware interface {
+	// ForProjects restituisce il build system da usare per analizzare i progetti nelle directory indicate
+	ForProjects(dirs []string) BuildSystem
+}
+
+// Analyze analizza i progetti indicati e costruisce il grafo delle dipendenze registrando l'origine
+// di ogni arco. Ogni progetto viene analizzato con il primo build system di opts.Systems che lo riconosce;
+// le directory non riconosciute vengono analizzate con il primo, così l'errore indica il file mancante.
+// Il grafo contiene archi di tutti i tipi: usa Filter per limitarlo ai tipi da considerare nell'ordinamento.
+func Analyze(projectNames []string, opts AnalyzeOptions) (*DependencyAnalysis, error) {
+	if len(opts.Systems) == 0 {
+		return nil, errors.New("nessun build system configurato per l'analisi dei progetti")
+	}
+
+	dirs := make([]string, len(projectNames))
+	for i, name := range projectNames {
+		dirs[i] = opts.ProjectDir(name)
+	}
+	systems := make([]BuildSystem, len(opts.Systems))
+	for i, system := range opts.Systems {
+		if aware, ok := system.(ProjectSetAware); ok {
+			system = aware.ForProjects(dirs)
+		}
+		systems[i] = system
+	}
+
+	// Registra gli artifact dichiarati nella configurazione; quelli dichiarati nei file di build hanno la precedenza
+	registry := NewArtifactRegistry()
+	for _, name := range projectNames {
+		for _, identifier := range opts.Provides[name] {
+			registry.Register(identifier, name)
+		}
+	}
+
+	// Parse tutti i progetti in una sola passata
+	projects := make(map[string]*Project)
+	for i, name := range projectNames {
+		system, found := Detect(dirs[i], systems...)
+		if !found {
+			system = systems[0]
+		}
+
+		project, err := system.ParseProject(name, dirs[i])
+		if err != nil {
+			return nil, err
+		}
+		projects[name] = project
+
+		// Registra il progetto principale e i suoi moduli per gestire dipendenze indirette
+		system.RegisterArtifacts(project, registry)
+	}
+
+	// Costruisci il grafo delle dipendenze tra i progetti selezionati
+	analysis := &DependencyAnalysis{
+		Graph:   graph.NewDependencyGraph(),
+		Origins: make(map[graph.Edge][]EdgeOrigin),
+	}
+	for _, name := range projectNames {
+		project := projects[name]
+		analysis.Warnings = append(analysis.Warnings, project.Warnings...)
+		dependencies := make([]string, 0)
+
+		for _, dep := range project.Dependencies {
+			// Solo le dipendenze verso i progetti selezionati generano archi; le self-dependency vengono ignorate
+			depProjectName, exists := registry.Lookup(dep.Identifier)
+			if !exists || depProjectName == name {
+				continue
+			}
+			if !slices.Contains(dependencies, depProjectName) {
+				dependencies = append(dependencies, depProjectName)
+			}
+			edge := graph.Edge{From: name, To: depProjectName}
+			analysis.Origins[edge] = append(analysis.Origins[edge], EdgeOrigin{
+				Source:     dep.Source,
+				Identifier: dep.Identifier,
+				Kind:       dep.Kind,
+			})
+		}
+
+		// Dipendenze in ordine alfabetico per un output stabile tra un'esecuzione e l'altra
+		sort.Strings(dependencies)
+		analysis.Graph.AddNode(name, dependencies)
+	}
+
+	return analysis, nil
+}
+
+// EdgeKinds restituisce i tipi di dipendenza che generano l'arco indicato, in ordine alfabetico
+func (a *DependencyAnalysis) EdgeKinds(edge graph.Edge) []DependencyKind {
+	kinds := make([]DependencyKind, 0)
+	for _, origin := range a.Origins[edge] {
+		if !slices.Contains(kinds, origin.Kind) {
+			kinds = append(kinds, origin.Kind)
+		}
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+// Filter restituisce una copia dell'analisi che contiene solo gli archi generati
+// da almeno una dichiarazione dei tipi indicati
+func (a *DependencyAnalysis) Filter(kinds []DependencyKind) *DependencyAnalysis {
+	filtered := &DependencyAnalysis{
+		Graph:    graph.NewDependencyGraph(),
+		Origins:  make(map[graph.Edge][]EdgeOrigin),
+		Warnings: a.Warnings,
+	}
+
+	for _, name := range a.Graph.Nodes() {
+		dependencies := make([]string, 0)
+		for _, dep := range a.Graph[name] {
+			edge := graph.Edge{From: name, To: dep}
+			for _, origin := range a.Origins[edge] {
+				if slices.Contains(kinds, origin.Kind) {
+					filtered.Origins[edge] = append(filtered.Origins[edge], origin)
+				}
+			}
+			if len(filtered.Origins[edge]) > 0 {
+				dependencies = append(dependencies, dep)
+			}
+		}
+		filtered.Graph.AddNode(name, dependencies)
+	}
+
+	return filtered
+}
+
+// Restrict restituisce una copia dell'analisi limitata ai progetti in upstream con le loro
+// dipendenze transitive e ai progetti in downstream con tutti quelli che dipendono da essi
+func (a *DependencyAnalysis) Restrict(upstream, downstream []string) (*DependencyAnalysis, error) {
+	restricted, err := a.Graph.Restrict(upstream, downstream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependencyAnalysis{
+		Graph:    restricted,
+		Origins:  a.Origins,
+		Warnings: a.Warnings,
+	}, nil
+}
+
+// TopologicalSort ordina i progetti analizzati in base alle loro dipendenze.
+// A parità di dipendenze vengono prima i progetti in priority, poi gli altri in ordine alfabetico.
+// In presenza di cicli restituisce un *CycleError che indica, per ogni arco del ciclo,
+// il file di build e la dipendenza che lo hanno generato.
+func (a *DependencyAnalysis) TopologicalSort(priority []string) ([]string, error) {
+	sorted, err := a.Graph.TopologicalSortWithPriority(priority)
+	if err != nil {
+		var cycleErr *graph.CycleError
+		if errors.As(err, &cycleErr) {
+			return nil, &CycleError{Cycles: cycleErr.Cycles, Origins: a.Origins}
+		}
+		return nil, err
+	}
+	return sorted, nil
+}
+
+// ParseEdgeKinds converte una lista di nomi (es. dalla configurazione) nei tipi di dipendenza.
+// Se la lista è vuota restituisce DefaultEdgeKinds.
+func ParseEdgeKinds(names []string) ([]DependencyKind, error) {
+	if len(names) == 0 {
+		return DefaultEdgeKinds, nil
+	}
+
+	kinds := make([]DependencyKind, 0, len(names))
+	for _, name := range names {
+		kind := DependencyKind(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(AllDependencyKinds, kind) {
+			return nil, fmt.Errorf("tipo di dipendenza '%s' non valido (valori ammessi: %v)", name, AllDependencyKinds)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
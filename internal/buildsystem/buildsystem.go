@@ -1,14 +1,85 @@
 // Package buildsystem fornisce interfacce comuni per diversi sistemi di build
 package buildsystem
 
+import "github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+
+// BuildSystem è un sistema di build supportato (es. Maven, Gradle): riconosce i propri progetti,
+// ne estrae identificatore e dipendenze, costruisce il comando di build e ne interpreta l'output
+type BuildSystem interface {
+	// Name restituisce il nome del build system (es. "maven")
+	Name() string
+	// Detect indica se la directory contiene un progetto di questo build system
+	Detect(dir string) bool
+	// ParseProject analizza il progetto nella directory dir estraendo identificatore e dipendenze
+	ParseProject(projectName, dir string) (*Project, error)
+	// RegisterArtifacts registra nel registry gli artifact prodotti dal progetto, inclusi i suoi moduli
+	RegisterArtifacts(project *Project, registry *ArtifactRegistry)
+	// BuildCommand restituisce il comando che esegue goal o task nella directory dir
+	BuildCommand(dir string, tasks []string, opts BuildOptions) Command
+	// OutputParser restituisce un nuovo parser che riconosce fasi, test e problemi nell'output della build
+	OutputParser() executor.OutputParser
+	// UsesJDK indica se la build richiede un JDK: solo per questi build system viene scelto il JAVA_HOME
+	UsesJDK() bool
+}
+
+// Command è il comando che esegue la build di un progetto
+type Command struct {
+	Executable string   // Eseguibile (es. mvn, il Gradle Wrapper del progetto, npm)
+	Args       []string // Argomenti dell'eseguibile
+	Env        []string // Variabili d'ambiente aggiuntive nella forma NOME=valore
+}
+
+// BuildOptions sono le opzioni del comando di build comuni a tutti i build system
+type BuildOptions struct {
+	SkipTests bool     // Non esegue i test
+	Args      []string // Argomenti aggiuntivi specifici del build system, dopo goal e task
+}
+
+// Detect restituisce il primo build system tra quelli indicati che riconosce la directory
+func Detect(dir string, systems ...BuildSystem) (BuildSystem, bool) {
+	for _, system := range systems {
+		if system.Detect(dir) {
+			return system, true
+		}
+	}
+	return nil, false
+}
+
 // Project rappresenta un progetto generico indipendente dal build system
 type Project struct {
-	Name         string   // Nome del progetto (directory)
-	Path         string   // Percorso completo del progetto
-	Identifier   string   // Identificatore univoco del progetto (es. groupId:artifactId per Maven)
-	Dependencies []string // Lista di identificatori delle dipendenze
+	Name         string       // Nome del progetto (directory)
+	Path         string       // Percorso completo del progetto
+	Identifier   string       // Identificatore univoco del progetto (es. groupId:artifactId per Maven)
+	Dependencies []Dependency // Lista delle dipendenze dichiarate
+	Warnings     []string     // Avvisi emersi durante l'analisi (es. valori non risolti)
+}
+
+// Dependency rappresenta una dipendenza dichiarata da un progetto
+type Dependency struct {
+	Identifier string         // Identificatore dell'artifact richiesto (es. groupId:artifactId per Maven)
+	Source     string         // File in cui la dipendenza è dichiarata (es. percorso del pom.xml)
+	Kind       DependencyKind // Tipo di relazione con l'artifact richiesto
 }
 
+// DependencyKind identifica il tipo di relazione che genera una dipendenza tra progetti
+type DependencyKind string
+
+const (
+	// KindDependency è una dipendenza diretta (es. <dependency> Maven)
+	KindDependency DependencyKind = "dependency"
+	// KindParent è il progetto parent da cui si eredita la configurazione
+	KindParent DependencyKind = "parent"
+	// KindImport è un BOM importato (es. dependencyManagement con scope import)
+	KindImport DependencyKind = "import"
+	// KindPlugin è un plugin di build o una dipendenza di un plugin
+	KindPlugin DependencyKind = "plugin"
+	// KindExtension è un'estensione di build
+	KindExtension DependencyKind = "extension"
+)
+
+// AllDependencyKinds elenca tutti i tipi di dipendenza riconosciuti
+var AllDependencyKinds = []DependencyKind{KindDependency, KindParent, KindImport, KindPlugin, KindExtension}
+
 // ArtifactRegistry mantiene la mappatura tra identificatori di artifact e nomi di progetti
 type ArtifactRegistry struct {
 	artifactToProject map[string]string
@@ -0,0 +1,38 @@
+package buildsystem
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+)
+
+// CycleError è l'errore restituito quando i progetti hanno dipendenze circolari.
+// Per ogni componente fortemente connessa riporta un ciclo e, per ogni arco del ciclo,
+// il file di build e l'identificatore della dipendenza che lo hanno generato.
+type CycleError struct {
+	Cycles  []graph.Cycle
+	Origins map[graph.Edge][]EdgeOrigin
+}
+
+// Error restituisce una descrizione su più righe dei cicli rilevati
+func (e *CycleError) Error() string {
+	var b strings.Builder
+	b.WriteString("dipendenze circolari rilevate tra i progetti:")
+
+	for _, cycle := range e.Cycles {
+		fmt.Fprintf(&b, "\n  %s", cycle.String())
+		for _, edge := range cycle.Edges() {
+			for _, origin := range e.Origins[edge] {
+				fmt.Fprintf(&b, "\n    %s -> %s: %s dichiara %s", edge.From, edge.To, origin.Source, origin.Identifier)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// Unwrap restituisce l'errore del grafo sottostante, così errors.As funziona anche con *graph.CycleError
+func (e *CycleError) Unwrap() error {
+	return &graph.CycleError{Cycles: e.Cycles}
+}
@@ -0,0 +1,23 @@
+package cmdutil
+
+import (
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/config"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/gradle"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/npm"
+)
+
+// OtherBuildSystems sono i build system supportati oltre a Maven, nell'ordine in cui vengono provati
+// per riconoscere un progetto (Maven ha sempre la precedenza)
+var OtherBuildSystems = []buildsystem.BuildSystem{gradle.BuildSystem{}, npm.BuildSystem{}}
+
+// AnalyzeProjects analizza le dipendenze tra i progetti selezionati del profilo, ovunque si trovino
+// e qualunque sia il loro build system
+func AnalyzeProjects(cfg *config.Config) (*buildsystem.DependencyAnalysis, error) {
+	return buildsystem.Analyze(cfg.SelectedProjects, buildsystem.AnalyzeOptions{
+		ProjectDir: cfg.ProjectDir,
+		Systems:    append([]buildsystem.BuildSystem{maven.BuildSystem{}}, OtherBuildSystems...),
+		Provides:   cfg.ProvidedArtifacts(),
+	})
+}
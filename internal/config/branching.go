@@ -0,0 +1,74 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+// Valori predefiniti della politica dei branch, usati per i campi non configurati
+const (
+	DefaultIntegrationBranch = "develop"
+	DefaultReleaseBranch     = "deploy/*"
+	DefaultRemote            = "origin"
+)
+
+// BranchingPolicy descrive il modello di branch di un repository usato da git update
+type BranchingPolicy struct {
+	IntegrationBranch string   `json:"integration_branch,omitempty"` // Branch di integrazione in cui confluiscono le modifiche (default: develop)
+	ReleaseBranches   []string `json:"release_branches,omitempty"`   // Pattern dei branch di rilascio, aggiornati con pull invece che con merge (default: deploy/*)
+	Remote            string   `json:"remote,omitempty"`             // Remote da cui aggiornare i branch (default: origin)
+}
+
+// BranchingPolicy restituisce la politica dei branch di un progetto: ogni campo configurato nel progetto
+// ha la precedenza su quello del profilo, i campi assenti in entrambi assumono i valori predefiniti
+func (c *Config) BranchingPolicy(projectName string) BranchingPolicy {
+	policy := BranchingPolicy{
+		IntegrationBranch: DefaultIntegrationBranch,
+		ReleaseBranches:   []string{DefaultReleaseBranch},
+		Remote:            DefaultRemote,
+	}
+	policy.merge(c.Branching)
+	if settings, found := c.Projects[projectName]; found {
+		policy.merge(settings.Branching)
+	}
+	return policy
+}
+
+// merge sovrascrive i campi della politica con quelli configurati in override
+func (p *BranchingPolicy) merge(override *BranchingPolicy) {
+	if override == nil {
+		return
+	}
+	if override.IntegrationBranch != "" {
+		p.IntegrationBranch = override.IntegrationBranch
+	}
+	if len(override.ReleaseBranches) > 0 {
+		p.ReleaseBranches = override.ReleaseBranches
+	}
+	if override.Remote != "" {
+		p.Remote = override.Remote
+	}
+}
+
+// IsIntegrationBranch indica se branch è il branch di integrazione
+func (p BranchingPolicy) IsIntegrationBranch(branch string) bool {
+	return branch == p.IntegrationBranch
+}
+
+// IsReleaseBranch indica se branch corrisponde a uno dei pattern dei branch di rilascio.
+// I pattern sono glob (es. release/v*); quelli che terminano con "/*" includono anche
+// i branch annidati, quindi deploy/* corrisponde a deploy/2024/q1.
+func (p BranchingPolicy) IsReleaseBranch(branch string) bool {
+	for _, pattern := range p.ReleaseBranches {
+		if prefix, found := strings.CutSuffix(pattern, "/*"); found && !strings.ContainsAny(prefix, "*?[") {
+			if strings.HasPrefix(branch, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+// TestBranchingPolicy verifica i valori predefiniti, la priorità delle impostazioni del progetto
+// e il riconoscimento dei branch di rilascio
+func TestBranchingPolicy(t *testing.T) {
+	cfg := Config{
+		Branching: &BranchingPolicy{Remote: "upstream"},
+		Projects: map[string]ProjectSettings{
+			"web": {Branching: &BranchingPolicy{IntegrationBranch: "main", ReleaseBranches: []string{"release/v*"}}},
+		},
+	}
+
+	policy := cfg.BranchingPolicy("core")
+	if policy.IntegrationBranch != DefaultIntegrationBranch || policy.Remote != "upstream" {
+		t.Errorf("politica di core = %+v, attesi develop e upstream", policy)
+	}
+	if !policy.IsReleaseBranch("deploy/2024/q1") || policy.IsReleaseBranch("deployment") {
+		t.Error("deploy/* deve corrispondere ai branch sotto deploy/, anche annidati")
+	}
+
+	policy = cfg.BranchingPolicy("web")
+	if policy.IntegrationBranch != "main" || policy.Remote != "upstream" {
+		t.Errorf("politica di web = %+v, attesi main e upstream", policy)
+	}
+	if !policy.IsReleaseBranch("release/v1.2") || policy.IsReleaseBranch("deploy/1.0") {
+		t.Error("web deve usare solo i pattern di rilascio del progetto")
+	}
+}
@@ -20,13 +20,49 @@ const (
 	ConfigDirPermissions = 0755
 	// ConfigFilePermissions sono i permessi per il file di configurazione
 	ConfigFilePermissions = 0644
+	// ProfilesDataDirName è la directory che contiene i dati di esecuzione dei profili
+	ProfilesDataDirName = "profiles"
 )
 
 // Config rappresenta la struttura della configurazione di projman
 type Config struct {
-	RootOfProjects   string   `json:"root_of_projects"`        // Percorso root contenente tutti i progetti
-	SelectedProjects []string `json:"selected_projects"`       // Lista dei progetti selezionati dall'utente
-	MavenProfile     string   `json:"maven_profile,omitempty"` // Profilo Maven opzionale (es: "local-dev", "production")
+	RootOfProjects   string                     `json:"root_of_projects"`           // Percorso root contenente i progetti (la prima root se sono più di una)
+	Roots            []string                   `json:"roots,omitempty"`            // Tutte le directory root del profilo (vuoto = solo root_of_projects)
+	SelectedProjects []string                   `json:"selected_projects"`          // Lista dei progetti selezionati dall'utente
+	DiscoveryDepth   int                        `json:"discovery_depth,omitempty"`  // Profondità massima di ricerca dei progetti sotto la root (default: 3)
+	MavenProfile     string                     `json:"maven_profile,omitempty"`    // Profili Maven opzionali separati da virgola (es: "local-dev", "a,b,!c")
+	BuildPriority    []string                   `json:"build_priority,omitempty"`   // Progetti da elaborare per primi quando l'ordine tra loro è indifferente
+	EdgeKinds        []string                   `json:"edge_kinds,omitempty"`       // Tipi di dipendenza considerati nell'ordinamento (default: dependency, parent, import)
+	MavenPhases      []MavenPhase               `json:"maven_phases,omitempty"`     // Fasi aggiuntive mostrate durante le build Maven
+	GradleTasks      []string                   `json:"gradle_tasks,omitempty"`     // Task eseguiti da mvn install sui progetti Gradle (default: clean build)
+	NpmScript        string                     `json:"npm_script,omitempty"`       // Script eseguito da mvn install sui pacchetti npm e pnpm (default: build)
+	MavenSettings    string                     `json:"maven_settings,omitempty"`   // File settings.xml passato a Maven con -s
+	LocalRepository  string                     `json:"local_repository,omitempty"` // Repository locale Maven (-Dmaven.repo.local)
+	Offline          bool                       `json:"offline,omitempty"`          // Esegue Maven offline (-o)
+	MavenProperties  map[string]string          `json:"maven_properties,omitempty"` // Proprietà aggiuntive passate con -Dchiave=valore
+	SystemMaven      bool                       `json:"system_maven,omitempty"`     // Usa sempre Maven dal PATH, anche se il progetto fornisce mvnw
+	MavenHome        string                     `json:"maven_home,omitempty"`       // Installazione Maven da usare al posto di mvnw e del PATH
+	JavaHome         string                     `json:"java_home,omitempty"`        // JDK dei progetti che non dichiarano una versione Java
+	JavaHomes        map[string]string          `json:"java_homes,omitempty"`       // JDK per versione principale (es. "11" -> /opt/jdk-11)
+	Branching        *BranchingPolicy           `json:"git_branching,omitempty"`    // Modello di branch usato da git update (default: develop, deploy/*, origin)
+	Projects         map[string]ProjectSettings `json:"projects,omitempty"`         // Impostazioni specifiche dei singoli progetti
+}
+
+// ProjectSettings contiene le impostazioni di un singolo progetto, prioritarie su quelle del profilo
+type ProjectSettings struct {
+	Root      string           `json:"root,omitempty"`          // Directory root in cui è stato trovato il progetto
+	JavaHome  string           `json:"java_home,omitempty"`     // JDK con cui compilare il progetto
+	Provides  []string         `json:"provides,omitempty"`      // Artifact prodotti dal progetto ma non dichiarati nei file di build (es. pacchetti npm generati)
+	Branching *BranchingPolicy `json:"git_branching,omitempty"` // Modello di branch del repository, prioritario su quello del profilo
+}
+
+// MavenPhase associa le esecuzioni di un plugin Maven a una fase mostrata durante la build.
+// Plugin e goal sono pattern glob; le fasi configurate hanno la precedenza su quelle predefinite.
+type MavenPhase struct {
+	Plugin string `json:"plugin"`          // Pattern del plugin (es. "frontend" o "maven-*-plugin")
+	Goal   string `json:"goal,omitempty"`  // Pattern del goal (vuoto = qualsiasi goal)
+	Name   string `json:"name"`            // Nome della fase (es. "FRONTEND")
+	Label  string `json:"label,omitempty"` // Testo mostrato nello spinner (default: name)
 }
 
 // ProfileConfig rappresenta la struttura che contiene tutti i profili e il profilo corrente
@@ -219,6 +255,29 @@ func saveProfileConfig(profileCfg ProfileConfig) error {
 	return nil
 }
 
+// ProfileDataDir restituisce la directory in cui salvare i dati di esecuzione del profilo
+// corrente (es. lo stato dell'ultima installazione), creandola se non esiste
+func ProfileDataDir() (string, error) {
+	profileName, err := GetCurrentProfile()
+	if err != nil {
+		return "", err
+	}
+	if profileName == "" {
+		return "", fmt.Errorf("nessun profilo attivo")
+	}
+
+	userConfigDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("impossibile ottenere la directory di configurazione: %w", err)
+	}
+
+	dataDir := filepath.Join(userConfigDir, ConfigDirName, ProfilesDataDirName, profileName)
+	if err := os.MkdirAll(dataDir, ConfigDirPermissions); err != nil {
+		return "", fmt.Errorf("impossibile creare la directory dei dati del profilo: %w", err)
+	}
+	return dataDir, nil
+}
+
 // CheckAndGetDirectory verifica che il percorso fornito sia una directory valida
 func CheckAndGetDirectory(directory string) (string, error) {
 	info, err := os.Stat(directory)
@@ -242,7 +301,9 @@ func CheckAndGetDirectory(directory string) (string, error) {
 	return absPath, nil
 }
 
-// LoadAndValidateConfig carica e valida la configurazione
+// LoadAndValidateConfig carica la configurazione e riallinea i progetti selezionati alle directory root
+// del profilo per i comandi che li compilano: restituisce un errore se un progetto selezionato non è
+// più risolvibile. I comandi che si limitano a leggere la selezione usano RefreshProjectsForReading.
 func LoadAndValidateConfig() (*Config, error) {
 	cfg, err := LoadSettings()
 	if err != nil {
@@ -250,17 +311,27 @@ func LoadAndValidateConfig() (*Config, error) {
 		pterm.Info.Println("Esegui prima 'projman init <directory>' per configurare i progetti")
 		return nil, err
 	}
+	if err := cfg.RefreshProjects(); err != nil {
+		pterm.Error.Println(err)
+		return nil, err
+	}
 	return &cfg, nil
 }
 
 // SelectProjectsToUpdate mostra un prompt per selezionare i progetti da aggiornare
 func SelectProjectsToUpdate(cfg *Config) ([]string, error) {
-	projUri, err := project.Discover(cfg.RootOfProjects)
+	projUri, err := project.DiscoverAll(cfg.ProjectRoots(), cfg.DiscoveryDepth)
 	if err != nil {
 		pterm.Error.Println("Errore nella scansione dei progetti:", err)
 		return nil, err
 	}
 
+	// Riallinea la selezione salvata ai nomi attuali, così resta preselezionata nel prompt;
+	// i progetti non risolti vengono solo segnalati perché l'utente sta per selezionarli di nuovo
+	if err := cfg.ResolveProjects(projUri); err != nil {
+		pterm.Warning.Println(err)
+	}
+
 	names := project.Names(projUri)
 	selectedNames, err := pterm.DefaultInteractiveMultiselect.
 		WithOptions(names).
@@ -271,6 +342,7 @@ func SelectProjectsToUpdate(cfg *Config) ([]string, error) {
 		return nil, err
 	}
 
+	cfg.RememberRoots(projUri, selectedNames)
 	return selectedNames, nil
 }
 
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/project"
+)
+
+// ProjectRoots restituisce le directory root del profilo. I profili creati con una sola root
+// hanno solo RootOfProjects.
+func (c *Config) ProjectRoots() []string {
+	if len(c.Roots) > 0 {
+		return c.Roots
+	}
+	return []string{c.RootOfProjects}
+}
+
+// ProjectDir restituisce la directory di un progetto: quella sotto la root in cui è stato trovato
+// o, se la root non è stata registrata, quella sotto la prima root del profilo.
+func (c *Config) ProjectDir(projectName string) string {
+	root := c.ProjectRoots()[0]
+	if settings, found := c.Projects[projectName]; found && settings.Root != "" {
+		root = settings.Root
+	}
+	return filepath.Join(root, filepath.FromSlash(project.RelativePath(projectName)))
+}
+
+// RememberRoots registra per ogni progetto selezionato la root in cui è stato trovato.
+// Con una sola root non registra nulla: tutti i progetti si trovano sotto RootOfProjects.
+func (c *Config) RememberRoots(projects []project.Project, selected []string) {
+	if len(c.ProjectRoots()) < 2 {
+		return
+	}
+	roots := make(map[string]string, len(projects))
+	for _, proj := range projects {
+		roots[proj.Name] = proj.Root
+	}
+	for _, projectName := range selected {
+		root, found := roots[projectName]
+		if !found {
+			continue
+		}
+		if c.Projects == nil {
+			c.Projects = make(map[string]ProjectSettings)
+		}
+		settings := c.Projects[projectName]
+		settings.Root = root
+		c.Projects[projectName] = settings
+	}
+}
+
+// RefreshProjects scansiona le directory root del profilo e riallinea i progetti selezionati
+// ai progetti trovati (vedi ResolveProjects)
+func (c *Config) RefreshProjects() error {
+	projects, err := project.DiscoverAll(c.ProjectRoots(), c.DiscoveryDepth)
+	if err != nil {
+		return fmt.Errorf("errore nella scansione dei progetti: %w", err)
+	}
+	return c.ResolveProjects(projects)
+}
+
+// ResolveProjects riallinea i progetti selezionati ai progetti trovati nelle directory root.
+// Il nome di un progetto dipende da tutte le root: un percorso presente in più root viene qualificato
+// con "<root>:<percorso>", quindi una root o un progetto aggiunti dopo la selezione possono cambiare
+// il nome di un progetto già selezionato. I nomi non più validi vengono sostituiti con quello attuale
+// nella selezione, nelle impostazioni dei progetti e nelle priorità, e le root registrate aggiornate.
+// Restituisce un errore che elenca i progetti selezionati non più trovati o che corrispondono
+// a più progetti: i loro nomi restano invariati.
+func (c *Config) ResolveProjects(projects []project.Project) error {
+	unresolved := &UnresolvedProjectsError{}
+	for _, name := range slices.Clone(c.SelectedProjects) {
+		matches := c.matchProject(projects, name)
+		switch len(matches) {
+		case 0:
+			unresolved.add(name, fmt.Sprintf("'%s' non trovato", name))
+			continue
+		case 1:
+		default:
+			unresolved.add(name, fmt.Sprintf("'%s' corrisponde a più progetti (%s)",
+				name, strings.Join(project.Names(matches), ", ")))
+			continue
+		}
+
+		proj := matches[0]
+		if proj.Name != name {
+			c.renameProject(name, proj.Name)
+		}
+		if settings, found := c.Projects[proj.Name]; found && settings.Root != "" {
+			settings.Root = proj.Root
+			c.Projects[proj.Name] = settings
+		}
+	}
+	c.RememberRoots(projects, c.SelectedProjects)
+
+	if len(unresolved.Names) > 0 {
+		return unresolved
+	}
+	return nil
+}
+
+// RefreshProjectsForReading è RefreshProjects per i comandi che non compilano i progetti selezionati:
+// i progetti non più risolti vengono esclusi dalla selezione in memoria, senza modificare quella salvata,
+// e restituiti perché il chiamante possa segnalarli. Restituisce un errore solo se la scansione fallisce.
+func (c *Config) RefreshProjectsForReading() ([]string, error) {
+	err := c.RefreshProjects()
+	var unresolved *UnresolvedProjectsError
+	if !errors.As(err, &unresolved) {
+		return nil, err
+	}
+	c.SelectedProjects = slices.DeleteFunc(c.SelectedProjects, func(name string) bool {
+		return slices.Contains(unresolved.Names, name)
+	})
+	return unresolved.Names, nil
+}
+
+// UnresolvedProjectsError elenca i progetti selezionati non più trovati nelle directory root del profilo
+// o che corrispondono a più progetti
+type UnresolvedProjectsError struct {
+	Names   []string // Nomi dei progetti selezionati non risolti
+	details []string
+}
+
+// add registra un progetto non risolto con il motivo
+func (e *UnresolvedProjectsError) add(name, detail string) {
+	e.Names = append(e.Names, name)
+	e.details = append(e.details, detail)
+}
+
+// Error restituisce la descrizione dei progetti non risolti con il suggerimento per correggere la selezione
+func (e *UnresolvedProjectsError) Error() string {
+	return fmt.Sprintf("progetti selezionati non risolti nelle directory root del profilo: %s; "+
+		"aggiorna la selezione (es. con 'projman init')", strings.Join(e.details, ", "))
+}
+
+// matchProject restituisce i progetti che corrispondono a un nome selezionato: il progetto con lo stesso
+// nome o, se il nome non è più valido, quelli con lo stesso percorso relativo nella root indicata
+// dalla qualificazione, registrata per il progetto o, in mancanza, nella prima root del profilo
+func (c *Config) matchProject(projects []project.Project, name string) []project.Project {
+	for _, proj := range projects {
+		if proj.Name == name {
+			return []project.Project{proj}
+		}
+	}
+
+	label, _, qualified := strings.Cut(name, project.RootSeparator)
+	root := c.ProjectRoots()[0]
+	if settings, found := c.Projects[name]; found && settings.Root != "" {
+		root = settings.Root
+	}
+
+	matches := make([]project.Project, 0)
+	for _, proj := range projects {
+		if project.RelativePath(proj.Name) != project.RelativePath(name) {
+			continue
+		}
+		if qualified && project.RootLabel(proj.Root) != label {
+			continue
+		}
+		if !qualified && filepath.Clean(proj.Root) != filepath.Clean(root) {
+			continue
+		}
+		matches = append(matches, proj)
+	}
+	return matches
+}
+
+// renameProject sostituisce il nome di un progetto nella selezione, nelle impostazioni e nelle priorità
+// (se il nuovo nome è già presente, il vecchio viene rimosso e le impostazioni esistenti restano invariate)
+func (c *Config) renameProject(oldName, newName string) {
+	c.SelectedProjects = renameInList(c.SelectedProjects, oldName, newName)
+	c.BuildPriority = renameInList(c.BuildPriority, oldName, newName)
+	if settings, found := c.Projects[oldName]; found {
+		delete(c.Projects, oldName)
+		if _, exists := c.Projects[newName]; !exists {
+			c.Projects[newName] = settings
+		}
+	}
+}
+
+// renameInList sostituisce oldName con newName in names, mantenendo l'ordine e senza duplicati
+func renameInList(names []string, oldName, newName string) []string {
+	if !slices.Contains(names, oldName) {
+		return names
+	}
+	renamed := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == oldName {
+			name = newName
+		}
+		if !slices.Contains(renamed, name) {
+			renamed = append(renamed, name)
+		}
+	}
+	return renamed
+}
+
+// ProvidedArtifacts restituisce per ogni progetto gli artifact configurati in provides
+func (c *Config) ProvidedArtifacts() map[string][]string {
+	provided := make(map[string][]string, len(c.Projects))
+	for projectName, settings := range c.Projects {
+		if len(settings.Provides) > 0 {
+			provided[projectName] = settings.Provides
+		}
+	}
+	return provided
+}
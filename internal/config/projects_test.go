@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/project"
+)
+
+// TestResolveProjects verifica che i nomi selezionati vengano riallineati quando una root aggiunta
+// o una nuova collisione cambia la qualificazione dei progetti
+func TestResolveProjects(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        Config
+		projects   []project.Project
+		selected   []string
+		priority   []string
+		settings   map[string]ProjectSettings
+		unresolved string
+	}{
+		{
+			name: "root aggiunta con lo stesso percorso",
+			cfg: Config{
+				RootOfProjects:   "/ws/platform",
+				Roots:            []string{"/ws/platform", "/ws/products"},
+				SelectedProjects: []string{"api", "core"},
+				BuildPriority:    []string{"api"},
+			},
+			projects: []project.Project{
+				{Name: "platform:api", Root: "/ws/platform"},
+				{Name: "products:api", Root: "/ws/products"},
+				{Name: "core", Root: "/ws/platform"},
+			},
+			selected: []string{"platform:api", "core"},
+			priority: []string{"platform:api"},
+			settings: map[string]ProjectSettings{"platform:api": {Root: "/ws/platform"}, "core": {Root: "/ws/platform"}},
+		},
+		{
+			name: "root registrata del progetto",
+			cfg: Config{
+				RootOfProjects:   "/ws/platform",
+				Roots:            []string{"/ws/platform", "/ws/products"},
+				SelectedProjects: []string{"web"},
+				Projects:         map[string]ProjectSettings{"web": {Root: "/ws/products", JavaHome: "/opt/jdk-21"}},
+			},
+			projects: []project.Project{
+				{Name: "platform:web", Root: "/ws/platform"},
+				{Name: "products:web", Root: "/ws/products"},
+			},
+			selected: []string{"products:web"},
+			settings: map[string]ProjectSettings{"products:web": {Root: "/ws/products", JavaHome: "/opt/jdk-21"}},
+		},
+		{
+			name: "collisione scomparsa",
+			cfg: Config{
+				RootOfProjects:   "/ws/platform",
+				Roots:            []string{"/ws/platform", "/ws/products"},
+				SelectedProjects: []string{"products:api"},
+			},
+			projects: []project.Project{{Name: "api", Root: "/ws/products"}},
+			selected: []string{"api"},
+			settings: map[string]ProjectSettings{"api": {Root: "/ws/products"}},
+		},
+		{
+			name: "progetto rimosso",
+			cfg: Config{
+				RootOfProjects:   "/ws/platform",
+				SelectedProjects: []string{"core", "legacy"},
+			},
+			projects:   []project.Project{{Name: "core", Root: "/ws/platform"}},
+			selected:   []string{"core", "legacy"},
+			unresolved: "'legacy' non trovato",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			err := cfg.ResolveProjects(tt.projects)
+			switch {
+			case tt.unresolved == "" && err != nil:
+				t.Fatalf("ResolveProjects() errore inatteso: %v", err)
+			case tt.unresolved != "" && (err == nil || !strings.Contains(err.Error(), tt.unresolved)):
+				t.Fatalf("ResolveProjects() = %v, atteso errore con %q", err, tt.unresolved)
+			}
+
+			if !reflect.DeepEqual(cfg.SelectedProjects, tt.selected) {
+				t.Errorf("selezione = %v, attesa %v", cfg.SelectedProjects, tt.selected)
+			}
+			if tt.priority != nil && !reflect.DeepEqual(cfg.BuildPriority, tt.priority) {
+				t.Errorf("priorità = %v, attesa %v", cfg.BuildPriority, tt.priority)
+			}
+			if tt.settings != nil && !reflect.DeepEqual(cfg.Projects, tt.settings) {
+				t.Errorf("impostazioni = %+v, attese %+v", cfg.Projects, tt.settings)
+			}
+		})
+	}
+}
+
+// TestRefreshProjectsForReading verifica che i comandi di sola lettura escludano i progetti
+// non più trovati invece di fallire
+func TestRefreshProjectsForReading(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "core"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "core", "pom.xml"), []byte("<project/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{RootOfProjects: root, SelectedProjects: []string{"legacy", "core"}}
+	unresolved, err := cfg.RefreshProjectsForReading()
+	if err != nil {
+		t.Fatalf("RefreshProjectsForReading() errore inatteso: %v", err)
+	}
+	if !reflect.DeepEqual(unresolved, []string{"legacy"}) || !reflect.DeepEqual(cfg.SelectedProjects, []string{"core"}) {
+		t.Errorf("RefreshProjectsForReading() = %v con selezione %v, attesi [legacy] e [core]", unresolved, cfg.SelectedProjects)
+	}
+
+	cfg = Config{RootOfProjects: root, SelectedProjects: []string{"legacy", "core"}}
+	var unresolvedErr *UnresolvedProjectsError
+	if err := cfg.RefreshProjects(); !errors.As(err, &unresolvedErr) || !reflect.DeepEqual(unresolvedErr.Names, []string{"legacy"}) {
+		t.Errorf("RefreshProjects() = %v, atteso UnresolvedProjectsError per legacy", err)
+	}
+}
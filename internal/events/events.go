@@ -0,0 +1,172 @@
+// Package events emette gli eventi di un'esecuzione come JSON delimitato da newline (NDJSON),
+// per integrare projman con dashboard e strumenti esterni
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// SchemaVersion è la versione dello schema degli eventi.
+// Va incrementata a ogni modifica non retrocompatibile dei campi.
+const SchemaVersion = 1
+
+// Type identifica il tipo di un evento
+type Type string
+
+const (
+	TypeRunStarted      Type = "run_started"      // Avvio dell'esecuzione, con il piano dei progetti
+	TypeProjectStarted  Type = "project_started"  // Avvio della build di un progetto
+	TypePhaseStarted    Type = "phase_started"    // Inizio di una fase Maven riconosciuta
+	TypeTestResults     Type = "test_results"     // Risultati di una classe di test
+	TypeProjectFinished Type = "project_finished" // Fine della build di un progetto (o progetto saltato)
+	TypeRunFinished     Type = "run_finished"     // Fine dell'esecuzione, con il riepilogo
+)
+
+// Esiti di un progetto riportati in project_finished
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// Phase descrive una fase Maven in esecuzione
+type Phase struct {
+	Name   string `json:"name"`
+	Label  string `json:"label"`
+	Plugin string `json:"plugin"`
+	Goal   string `json:"goal"`
+	Module string `json:"module"`
+}
+
+// TestResults contiene i contatori riportati da Surefire/Failsafe
+type TestResults struct {
+	Suite    string `json:"suite,omitempty"` // Classe di test (assente per i totali del modulo)
+	Run      int    `json:"run"`
+	Failures int    `json:"failures"`
+	Errors   int    `json:"errors"`
+	Skipped  int    `json:"skipped"`
+}
+
+// Summary riassume gli esiti dei progetti di un'esecuzione: ogni progetto del piano è contato una volta,
+// in base all'esito riportato nel suo evento project_finished
+type Summary struct {
+	Succeeded        int `json:"succeeded"`
+	Failed           int `json:"failed"`
+	Skipped          int `json:"skipped"`
+	AlreadyInstalled int `json:"already_installed,omitempty"` // Progetti saltati perché già installati nell'esecuzione ripresa (inclusi in skipped)
+}
+
+// Event è un singolo evento; i campi valorizzati dipendono dal tipo
+type Event struct {
+	SchemaVersion int          `json:"schema_version"`
+	Type          Type         `json:"type"`
+	Timestamp     time.Time    `json:"timestamp"`
+	RunID         string       `json:"run_id,omitempty"`
+	Project       string       `json:"project,omitempty"`
+	Projects      []string     `json:"projects,omitempty"`    // run_started: progetti nell'ordine del piano
+	Jobs          int          `json:"jobs,omitempty"`        // run_started: build concorrenti
+	Args          []string     `json:"args,omitempty"`        // project_started: argomenti della build
+	Phase         *Phase       `json:"phase,omitempty"`       // phase_started
+	Tests         *TestResults `json:"tests,omitempty"`       // test_results
+	Status        string       `json:"status,omitempty"`      // project_finished
+	DurationMs    int64        `json:"duration_ms,omitempty"` // project_finished, run_finished
+	ExitCode      *int         `json:"exit_code,omitempty"`   // project_finished (assente se il progetto è saltato)
+	Error         string       `json:"error,omitempty"`       // project_finished
+	Diagnostics   []string     `json:"diagnostics,omitempty"` // project_finished: problemi riconosciuti
+	LogPath       string       `json:"log_path,omitempty"`    // project_finished
+	Summary       *Summary     `json:"summary,omitempty"`     // run_finished
+}
+
+// Emitter scrive gli eventi di un'esecuzione, uno per riga.
+// È sicuro per l'uso concorrente; tutti i metodi accettano un Emitter nil e in quel caso non fanno nulla.
+type Emitter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	runID   string
+}
+
+// NewEmitter crea un emitter che scrive su writer gli eventi dell'esecuzione indicata
+func NewEmitter(writer io.Writer, runID string) *Emitter {
+	return &Emitter{encoder: json.NewEncoder(writer), runID: runID}
+}
+
+// emit completa i campi comuni e scrive l'evento
+func (e *Emitter) emit(event Event) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	event.SchemaVersion = SchemaVersion
+	event.Timestamp = time.Now()
+	event.RunID = e.runID
+	_ = e.encoder.Encode(event)
+}
+
+// RunStarted segnala l'avvio dell'esecuzione con i progetti nell'ordine del piano
+func (e *Emitter) RunStarted(projects []string, jobs int) {
+	e.emit(Event{Type: TypeRunStarted, Projects: projects, Jobs: jobs})
+}
+
+// ProjectStarted segnala l'avvio della build di un progetto
+func (e *Emitter) ProjectStarted(project string, args []string) {
+	e.emit(Event{Type: TypeProjectStarted, Project: project, Args: args})
+}
+
+// PhaseStarted segnala l'inizio di una fase Maven in un progetto
+func (e *Emitter) PhaseStarted(project string, phase Phase) {
+	e.emit(Event{Type: TypePhaseStarted, Project: project, Phase: &phase})
+}
+
+// TestResults segnala i risultati di una classe di test
+func (e *Emitter) TestResults(project string, results TestResults) {
+	e.emit(Event{Type: TypeTestResults, Project: project, Tests: &results})
+}
+
+// ProjectFinished segnala la fine della build di un progetto con durata e exit code di Maven
+func (e *Emitter) ProjectFinished(project string, duration time.Duration, runErr error, diagnostics []string, logPath string) {
+	exitCode := ExitCode(runErr)
+	event := Event{
+		Type:        TypeProjectFinished,
+		Project:     project,
+		Status:      StatusSucceeded,
+		DurationMs:  duration.Milliseconds(),
+		ExitCode:    &exitCode,
+		Diagnostics: diagnostics,
+		LogPath:     logPath,
+	}
+	if runErr != nil {
+		event.Status = StatusFailed
+		event.Error = runErr.Error()
+	}
+	e.emit(event)
+}
+
+// ProjectSkipped segnala un progetto non compilato (dipendenza fallita o esecuzione interrotta)
+func (e *Emitter) ProjectSkipped(project string, reason string) {
+	e.emit(Event{Type: TypeProjectFinished, Project: project, Status: StatusSkipped, Error: reason})
+}
+
+// RunFinished segnala la fine dell'esecuzione con il riepilogo degli esiti
+func (e *Emitter) RunFinished(duration time.Duration, summary Summary) {
+	e.emit(Event{Type: TypeRunFinished, DurationMs: duration.Milliseconds(), Summary: &summary})
+}
+
+// ExitCode restituisce l'exit code di un comando: 0 se non ci sono errori,
+// -1 se il comando non è terminato con un exit code (es. non avviato)
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
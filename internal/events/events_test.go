@@ -0,0 +1,60 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmitter(t *testing.T) {
+	var buf bytes.Buffer
+	emitter := NewEmitter(&buf, "20250101-120000")
+
+	emitter.RunStarted([]string{"core", "api"}, 2)
+	emitter.ProjectStarted("core", []string{"clean", "install"})
+	emitter.PhaseStarted("core", Phase{Name: "COMPILE", Label: "COMPILE @ core", Plugin: "compiler", Goal: "compile", Module: "core"})
+	emitter.TestResults("core", TestResults{Suite: "com.example.CoreTest", Run: 3, Failures: 1})
+	emitter.ProjectFinished("core", 1500*time.Millisecond, nil, nil, "/tmp/core.log")
+	emitter.ProjectFinished("api", time.Second, errors.New("boom"), []string{"App.java:3: error"}, "")
+	emitter.ProjectSkipped("web", "una dipendenza non è stata installata")
+	emitter.RunFinished(3*time.Second, Summary{Succeeded: 1, Failed: 1, Skipped: 1})
+
+	// Un emitter nil non deve emettere nulla né andare in panic
+	var disabled *Emitter
+	disabled.RunStarted(nil, 1)
+
+	expectedTypes := []Type{TypeRunStarted, TypeProjectStarted, TypePhaseStarted, TypeTestResults,
+		TypeProjectFinished, TypeProjectFinished, TypeProjectFinished, TypeRunFinished}
+
+	scanner := bufio.NewScanner(&buf)
+	events := make([]Event, 0)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Riga non valida %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != len(expectedTypes) {
+		t.Fatalf("Attesi %d eventi, ottenuti %d", len(expectedTypes), len(events))
+	}
+	for i, event := range events {
+		if event.Type != expectedTypes[i] || event.SchemaVersion != SchemaVersion || event.RunID != "20250101-120000" {
+			t.Errorf("Evento %d errato: %+v", i, event)
+		}
+	}
+
+	if succeeded := events[4]; succeeded.Status != StatusSucceeded || succeeded.ExitCode == nil || *succeeded.ExitCode != 0 || succeeded.DurationMs != 1500 {
+		t.Errorf("project_finished riuscito errato: %+v", succeeded)
+	}
+	if failed := events[5]; failed.Status != StatusFailed || failed.ExitCode == nil || *failed.ExitCode != -1 || failed.Error != "boom" {
+		t.Errorf("project_finished fallito errato: %+v", failed)
+	}
+	if skipped := events[6]; skipped.Status != StatusSkipped || skipped.ExitCode != nil {
+		t.Errorf("project_finished saltato errato: %+v", skipped)
+	}
+}
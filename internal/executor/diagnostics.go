@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DiagnosticKind identifica la categoria di un problema rilevato nell'output della build
+type DiagnosticKind string
+
+const (
+	DiagnosticCompilation DiagnosticKind = "compilation" // Errore del compilatore (file, riga, messaggio)
+	DiagnosticTest        DiagnosticKind = "test"        // Test fallito
+	DiagnosticDependency  DiagnosticKind = "dependency"  // Errore di risoluzione delle dipendenze
+	DiagnosticEnforcer    DiagnosticKind = "enforcer"    // Regola del maven-enforcer-plugin violata
+)
+
+// ansiPattern riconosce le sequenze di escape ANSI per i colori, presenti se la build forza l'output colorato
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Diagnostic descrive un problema rilevato nell'output di una build
+type Diagnostic struct {
+	Kind    DiagnosticKind
+	File    string // File sorgente (errori di compilazione)
+	Line    int    // Riga nel file (0 se non disponibile)
+	Column  int    // Colonna nella riga (0 se non disponibile)
+	Test    string // Nome del test fallito
+	Rule    string // Regola dell'enforcer violata
+	Message string
+}
+
+// String restituisce una descrizione su una riga del problema
+func (d Diagnostic) String() string {
+	switch d.Kind {
+	case DiagnosticCompilation:
+		location := d.File
+		if d.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, d.Line)
+		}
+		return fmt.Sprintf("%s: %s", location, d.Message)
+	case DiagnosticTest:
+		if d.Message == "" {
+			return d.Test
+		}
+		return fmt.Sprintf("%s: %s", d.Test, d.Message)
+	case DiagnosticEnforcer:
+		if d.Message == "" {
+			return d.Rule
+		}
+		return fmt.Sprintf("%s: %s", d.Rule, d.Message)
+	default:
+		return d.Message
+	}
+}
+
+// key identifica il problema per riconoscere le ripetizioni
+func (d Diagnostic) key() string {
+	return fmt.Sprintf("%s|%s|%d|%s|%s|%s", d.Kind, d.File, d.Line, d.Test, d.Rule, d.Message)
+}
+
+// StripANSI rimuove le sequenze di escape ANSI per i colori da una riga dell'output
+func StripANSI(line string) string {
+	return ansiPattern.ReplaceAllString(line, "")
+}
+
+// DiagnosticList raccoglie i problemi riconosciuti nell'output ignorando le ripetizioni
+// (es. Maven e Gradle ripetono gli errori nel riepilogo finale). Il valore zero è pronto all'uso.
+type DiagnosticList struct {
+	items []Diagnostic
+	seen  map[string]bool
+}
+
+// Add registra un problema se non è già stato rilevato. Restituisce true se il problema è nuovo.
+func (l *DiagnosticList) Add(diagnostic Diagnostic) bool {
+	if l.seen == nil {
+		l.seen = make(map[string]bool)
+	}
+	key := diagnostic.key()
+	if l.seen[key] {
+		return false
+	}
+	l.seen[key] = true
+	l.items = append(l.items, diagnostic)
+	return true
+}
+
+// SetMessage completa il messaggio del problema in posizione index (es. messaggi su più righe)
+func (l *DiagnosticList) SetMessage(index int, message string) {
+	l.items[index].Message = message
+}
+
+// Len restituisce il numero di problemi raccolti
+func (l *DiagnosticList) Len() int {
+	return len(l.items)
+}
+
+// Items restituisce una copia dei problemi raccolti, nell'ordine in cui sono comparsi
+func (l *DiagnosticList) Items() []Diagnostic {
+	return append([]Diagnostic(nil), l.items...)
+}
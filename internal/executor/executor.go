@@ -0,0 +1,498 @@
+package executor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+)
+
+// Phase rappresenta una fase della build riconosciuta nell'output
+type Phase struct {
+	Name        string // Nome leggibile della fase (es: "COMPILE")
+	Plugin      string // Nome del plugin che esegue la fase (solo Maven)
+	Goal        string // Goal del plugin o task eseguito
+	Module      string // Nome del modulo o del sottoprogetto
+	Description string // Descrizione breve per lo spinner
+}
+
+// TestResults contiene i contatori dei test eseguiti, per classe o totali del modulo
+type TestResults struct {
+	Suite    string // Classe di test (vuota per i totali del modulo)
+	Run      int
+	Failures int
+	Errors   int
+	Skipped  int
+}
+
+// PhaseTiming è la durata di una fase della build in un modulo
+type PhaseTiming struct {
+	Name     string
+	Module   string
+	Duration time.Duration
+}
+
+// BuildListener riceve le fasi e i risultati dei test riconosciuti nell'output della build
+type BuildListener interface {
+	PhaseStarted(phase Phase)
+	TestResults(results TestResults)
+}
+
+// EventKind identifica il tipo di evento riconosciuto in una riga dell'output
+type EventKind int
+
+const (
+	EventNone         EventKind = iota // Riga senza eventi (i problemi vengono comunque raccolti)
+	EventPhaseStarted                  // Inizio di una fase della build
+	EventTestStarted                   // Inizio dell'esecuzione di una classe di test
+	EventTestResults                   // Risultati dei test di una classe o di un modulo
+)
+
+// OutputEvent è un evento riconosciuto da un OutputParser in una riga dell'output
+type OutputEvent struct {
+	Kind    EventKind
+	Phase   Phase       // Fase iniziata (EventPhaseStarted)
+	Test    string      // Classe di test in esecuzione (EventTestStarted)
+	Results TestResults // Risultati dei test (EventTestResults)
+}
+
+// OutputParser interpreta l'output di un build system. Ogni build usa un nuovo parser,
+// che può conservare lo stato tra una riga e l'altra (es. messaggi su più righe).
+type OutputParser interface {
+	// ParseLine analizza una riga dell'output e restituisce l'evento riconosciuto
+	ParseLine(line string) OutputEvent
+	// Diagnostics restituisce i problemi riconosciuti nell'output, nell'ordine in cui sono comparsi
+	Diagnostics() []Diagnostic
+}
+
+// Executor esegue la build di un progetto mostrandone l'avanzamento: fasi, test e problemi
+// vengono riconosciuti nell'output dal parser del build system
+type Executor struct {
+	projectName    string
+	args           []string
+	parser         OutputParser
+	executable     string   // Eseguibile della build (es. mvn, gradlew, npm)
+	workDir        string   // Directory in cui avviare la build (vuoto = directory corrente)
+	env            []string // Variabili d'ambiente aggiuntive (es. MAVEN_OPTS)
+	javaHome       string   // JDK con cui eseguire la build (vuoto = JAVA_HOME dell'ambiente)
+	CurrentSpinner *pterm.SpinnerPrinter
+	currentPhase   *Phase
+	writer         io.Writer // Destinazione dello spinner in modalità compatta (nil = output standard)
+	logPath        string    // File in cui salvare l'output completo della build (vuoto = nessun log)
+	logFile        *os.File
+	listener       BuildListener // Destinatario opzionale di fasi e risultati dei test
+	phaseStartedAt time.Time     // Inizio della fase corrente
+	phaseTimings   []PhaseTiming // Durate delle fasi completate
+	warnings       []string      // Avvisi emersi durante la build (es. log non disponibile)
+	mu             sync.Mutex    // Serializza l'elaborazione delle righe lette da stdout e stderr
+}
+
+// logFilePermissions sono i permessi dei file di log delle build
+const logFilePermissions = 0644
+
+// NewExecutor crea un executor che esegue executable con gli argomenti indicati
+// e ne interpreta l'output con parser
+func NewExecutor(projectName, executable string, args []string, parser OutputParser) *Executor {
+	return &Executor{
+		projectName: projectName,
+		executable:  executable,
+		args:        args,
+		parser:      parser,
+	}
+}
+
+// WithWriter imposta la modalità compatta: l'avanzamento della build viene mostrato
+// su un'unica riga scritta su writer, invece che con uno spinner per ogni fase.
+// Usato dalle build concorrenti, dove ogni progetto ha la propria riga di progresso.
+func (e *Executor) WithWriter(writer io.Writer) *Executor {
+	e.writer = writer
+	return e
+}
+
+// WithWorkDir avvia la build nella directory dir (di norma la directory del progetto)
+func (e *Executor) WithWorkDir(dir string) *Executor {
+	e.workDir = dir
+	return e
+}
+
+// WithEnv aggiunge le variabili d'ambiente indicate (nella forma NOME=valore) a quelle del processo
+func (e *Executor) WithEnv(env []string) *Executor {
+	e.env = env
+	return e
+}
+
+// Executable restituisce l'eseguibile della build mostrato all'utente: gli script del progetto
+// (es. il Maven o il Gradle Wrapper) sono indicati con il percorso relativo alla directory del progetto
+func (e *Executor) Executable() string {
+	if e.workDir == "" || !filepath.IsAbs(e.executable) {
+		return e.executable
+	}
+	rel, err := filepath.Rel(e.workDir, e.executable)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return e.executable
+	}
+	return "." + string(filepath.Separator) + rel
+}
+
+// WithJavaHome esegue la build con il JDK indicato, impostando JAVA_HOME e anteponendo
+// la sua directory bin al PATH
+func (e *Executor) WithJavaHome(javaHome string) *Executor {
+	e.javaHome = javaHome
+	return e
+}
+
+// WithListener notifica al listener le fasi e i risultati dei test riconosciuti durante la build
+func (e *Executor) WithListener(listener BuildListener) *Executor {
+	e.listener = listener
+	return e
+}
+
+// WithLogFile salva l'output completo della build (stdout e stderr) nel file indicato,
+// creando le directory mancanti
+func (e *Executor) WithLogFile(path string) *Executor {
+	e.logPath = path
+	return e
+}
+
+// LogPath restituisce il percorso del file di log della build (vuoto se non configurato)
+func (e *Executor) LogPath() string {
+	return e.logPath
+}
+
+// Warnings restituisce gli avvisi emersi durante la build, che non ne hanno causato il fallimento
+func (e *Executor) Warnings() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.warnings...)
+}
+
+// warn registra un avviso e lo mostra rispettando la modalità di output: in modalità compatta
+// viene scritto sulla riga del progetto, per non scrivere nell'area delle righe di progresso
+func (e *Executor) warn(message string) {
+	e.mu.Lock()
+	e.warnings = append(e.warnings, message)
+	e.mu.Unlock()
+
+	if e.compact() {
+		_, _ = fmt.Fprintln(e.writer, e.compactText("⚠ "+message))
+		return
+	}
+	pterm.Warning.Println(message)
+}
+
+// PhaseTimings restituisce la durata di ogni fase della build, nell'ordine di esecuzione
+func (e *Executor) PhaseTimings() []PhaseTiming {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]PhaseTiming(nil), e.phaseTimings...)
+}
+
+// closePhase registra la durata della fase corrente; il chiamante deve possedere il lock
+func (e *Executor) closePhase() {
+	if e.currentPhase == nil || e.phaseStartedAt.IsZero() {
+		return
+	}
+	e.phaseTimings = append(e.phaseTimings, PhaseTiming{
+		Name:     e.currentPhase.Name,
+		Module:   e.currentPhase.Module,
+		Duration: time.Since(e.phaseStartedAt),
+	})
+	e.phaseStartedAt = time.Time{}
+}
+
+// Diagnostics restituisce i problemi riconosciuti nell'output della build,
+// nell'ordine in cui sono comparsi
+func (e *Executor) Diagnostics() []Diagnostic {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.parser.Diagnostics()
+}
+
+// Fail chiude lo spinner corrente segnalando l'errore della build
+// e indica dove trovare il log completo
+func (e *Executor) Fail(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	message := err.Error()
+	if e.logPath != "" {
+		message = fmt.Sprintf("%s (log: %s)", message, e.logPath)
+	}
+
+	if e.CurrentSpinner == nil {
+		pterm.Error.Println("  ", message)
+		return
+	}
+
+	if e.compact() {
+		e.CurrentSpinner.Fail(e.compactText(message))
+	} else {
+		e.CurrentSpinner.Fail("  ", message)
+	}
+	e.CurrentSpinner = nil
+}
+
+// openLog crea il file di log della build e scrive l'intestazione con il comando eseguito
+func (e *Executor) openLog() error {
+	if e.logPath == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.logPath), 0755); err != nil {
+		return fmt.Errorf("impossibile creare la directory dei log: %w", err)
+	}
+	logFile, err := os.OpenFile(e.logPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, logFilePermissions)
+	if err != nil {
+		return fmt.Errorf("impossibile creare il file di log: %w", err)
+	}
+
+	e.logFile = logFile
+	_, _ = fmt.Fprintf(logFile, "# %s\n", time.Now().Format(time.RFC3339))
+	if e.javaHome != "" {
+		_, _ = fmt.Fprintf(logFile, "# JAVA_HOME=%s\n", e.javaHome)
+	}
+	_, _ = fmt.Fprintf(logFile, "# $ %s %s\n\n", e.executable, strings.Join(e.args, " "))
+	return nil
+}
+
+// closeLog scrive l'esito della build in fondo al log e chiude il file
+func (e *Executor) closeLog(runErr error) {
+	if e.logFile == nil {
+		return
+	}
+
+	result := "OK"
+	if runErr != nil {
+		result = runErr.Error()
+	}
+	_, _ = fmt.Fprintf(e.logFile, "\n# %s - esito: %s\n", time.Now().Format(time.RFC3339), result)
+	_ = e.logFile.Close()
+	e.logFile = nil
+}
+
+// compact indica se l'executor è in modalità compatta (una sola riga di progresso)
+func (e *Executor) compact() bool {
+	return e.writer != nil
+}
+
+// compactText antepone il nome del progetto al testo mostrato nella riga di progresso
+func (e *Executor) compactText(text string) string {
+	return fmt.Sprintf("[%s] %s", e.projectName, text)
+}
+
+// startSpinner avvia uno spinner rispettando la modalità di output dell'executor
+func (e *Executor) startSpinner(text string) *pterm.SpinnerPrinter {
+	if e.compact() {
+		spinner, _ := pterm.DefaultSpinner.WithWriter(e.writer).Start(e.compactText(text))
+		return spinner
+	}
+	spinner, _ := pterm.DefaultSpinner.Start(text)
+	return spinner
+}
+
+// Run esegue la build mostrando le fasi con spinner
+func (e *Executor) Run() error {
+	// Mostra comando con Info (senza spinner che si chiude subito)
+	// In modalità compatta viene indicato solo l'eseguibile scelto, sulla riga del progetto
+	if e.compact() {
+		_, _ = fmt.Fprintln(e.writer, e.compactText("$ "+e.Executable()))
+	} else {
+		pterm.Info.Printf("$ %s %s\n", e.Executable(), strings.Join(e.args, " "))
+	}
+
+	// Prepara ed esegui il comando
+	cmd := exec.Command(e.executable, e.args...)
+	cmd.Dir = e.workDir
+	env := os.Environ()
+	if e.javaHome != "" {
+		env = javaEnvironment(env, e.javaHome)
+	}
+	cmd.Env = append(env, e.env...)
+
+	// Unisci stdout e stderr per catturare tutto l'output
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("errore stdout pipe: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("errore stderr pipe: %w", err)
+	}
+
+	// Prepara il file di log con l'output completo; senza log la build prosegue comunque
+	if err := e.openLog(); err != nil {
+		e.warn(err.Error())
+		e.logPath = ""
+	}
+
+	// Avvia il comando di build
+	if err := cmd.Start(); err != nil {
+		err = fmt.Errorf("errore avvio comando: %w", err)
+		e.closeLog(err)
+		return err
+	}
+
+	// Info spinner iniziale
+	e.CurrentSpinner = e.startSpinner("Starting build...")
+
+	// Leggi output in goroutine
+	done := make(chan bool, 2)
+	go e.readOutput(stdout, done)
+	go e.readOutput(stderr, done)
+
+	// Attendi completamento lettura
+	<-done
+	<-done
+
+	// Attendi fine comando; in caso di errore lo spinner resta attivo e viene chiuso da Fail
+	err = cmd.Wait()
+	e.mu.Lock()
+	e.closePhase()
+	if err == nil && e.CurrentSpinner != nil {
+		if e.compact() {
+			e.CurrentSpinner.Success(e.compactText("Build completed"))
+		} else {
+			e.CurrentSpinner.Success("Build completed")
+		}
+		e.CurrentSpinner = nil
+	}
+	e.mu.Unlock()
+	e.closeLog(err)
+	return err
+}
+
+// javaEnvironment restituisce l'ambiente env con JAVA_HOME impostata a javaHome
+// e la directory bin del JDK anteposta al PATH
+func javaEnvironment(env []string, javaHome string) []string {
+	result := make([]string, 0, len(env)+2)
+	path := ""
+	for _, entry := range env {
+		name, value, _ := strings.Cut(entry, "=")
+		switch {
+		case strings.EqualFold(name, "JAVA_HOME"):
+			continue
+		case strings.EqualFold(name, "PATH"): // Su Windows la variabile si chiama "Path"
+			path = value
+			continue
+		}
+		result = append(result, entry)
+	}
+
+	bin := filepath.Join(javaHome, "bin")
+	if path != "" {
+		bin += string(os.PathListSeparator) + path
+	}
+	return append(result, "JAVA_HOME="+javaHome, "PATH="+bin)
+}
+
+// readOutput legge l'output da uno stream
+func (e *Executor) readOutput(stream io.Reader, done chan bool) {
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		e.processOutputLine(scanner.Text())
+	}
+	done <- true
+}
+
+// processOutputLine processa una riga dell'output della build
+func (e *Executor) processOutputLine(line string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Il log contiene ogni riga, anche quelle non riconosciute
+	if e.logFile != nil {
+		_, _ = fmt.Fprintln(e.logFile, line)
+	}
+
+	event := e.parser.ParseLine(line)
+	switch event.Kind {
+	case EventPhaseStarted:
+		e.handlePhaseStart(event.Phase)
+	case EventTestStarted:
+		e.handleTestStart(event.Test)
+	case EventTestResults:
+		e.handleTestResults(event.Results)
+	}
+}
+
+// handlePhaseStart gestisce l'inizio di una nuova fase della build
+func (e *Executor) handlePhaseStart(phase Phase) {
+	// Se stessa fase, non fare nulla
+	if e.currentPhase != nil && e.currentPhase.Name == phase.Name {
+		return
+	}
+
+	e.closePhase()
+	e.currentPhase = &phase
+	e.phaseStartedAt = time.Now()
+	if e.listener != nil {
+		e.listener.PhaseStarted(phase)
+	}
+
+	// In modalità compatta aggiorna la riga di progresso esistente
+	if e.compact() && e.CurrentSpinner != nil {
+		e.CurrentSpinner.UpdateText(e.compactText(phase.Description))
+		return
+	}
+
+	// Completa fase precedente con Success
+	if e.CurrentSpinner != nil {
+		e.CurrentSpinner.Success()
+	}
+
+	// Avvia nuovo spinner per questa fase
+	e.CurrentSpinner = e.startSpinner(phase.Description)
+}
+
+// handleTestStart aggiorna lo spinner con la classe di test in esecuzione
+func (e *Executor) handleTestStart(testClass string) {
+	if e.CurrentSpinner == nil || e.currentPhase == nil {
+		return
+	}
+
+	// Estrai nome breve della classe
+	shortName := testClass
+	if idx := strings.LastIndex(testClass, "."); idx != -1 {
+		shortName = testClass[idx+1:]
+	}
+
+	e.updateSpinnerText(fmt.Sprintf("%s - %s", e.currentPhase.Description, shortName))
+}
+
+// handleTestResults aggiorna lo spinner con i risultati dei test
+func (e *Executor) handleTestResults(results TestResults) {
+	if e.listener != nil {
+		e.listener.TestResults(results)
+	}
+
+	if e.CurrentSpinner == nil || e.currentPhase == nil {
+		return
+	}
+
+	passed := results.Run - results.Failures - results.Errors - results.Skipped
+
+	var message string
+	if failed := results.Failures + results.Errors; failed > 0 {
+		message = fmt.Sprintf("%s - %d passed, %d failed", e.currentPhase.Description, passed, failed)
+	} else {
+		message = fmt.Sprintf("%s - %d passed", e.currentPhase.Description, passed)
+	}
+
+	e.updateSpinnerText(message)
+}
+
+// updateSpinnerText aggiorna il testo dello spinner corrente rispettando la modalità di output
+func (e *Executor) updateSpinnerText(text string) {
+	if e.compact() {
+		text = e.compactText(text)
+	}
+	e.CurrentSpinner.UpdateText(text)
+}
@@ -0,0 +1,203 @@
+// Package fingerprint registra lo stato di ogni progetto al momento dell'ultima build riuscita,
+// così da poter ricompilare solo i progetti modificati
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/exec"
+)
+
+// StoreFileName è il nome del file che contiene i fingerprint delle ultime build riuscite
+const StoreFileName = "fingerprints.json"
+
+// filePermissions sono i permessi del file dei fingerprint
+const filePermissions = 0644
+
+// Fingerprint descrive lo stato di un progetto: commit corrente, modifiche locali e comando della build
+type Fingerprint struct {
+	Head      string    `json:"head"`                 // Commit HEAD del repository git
+	DirtyHash string    `json:"dirty_hash,omitempty"` // Hash delle modifiche non committate (vuoto se pulito)
+	System    string    `json:"system,omitempty"`     // Build system che ha eseguito la build (es. maven, gradle, npm)
+	Args      []string  `json:"args"`                 // Argomenti passati al build system
+	BuiltAt   time.Time `json:"built_at,omitempty"`   // Momento della build riuscita
+}
+
+// Compute calcola il fingerprint del progetto nella directory indicata per il build system
+// e gli argomenti dati. Restituisce un errore se la directory non è un repository git.
+func Compute(projectPath, system string, args []string) (Fingerprint, error) {
+	head, err := exec.RunWithOutput("git", "-C", projectPath, "rev-parse", "HEAD")
+	if err != nil {
+		return Fingerprint{}, fmt.Errorf("impossibile leggere il commit corrente: %w", err)
+	}
+
+	dirtyHash, err := computeDirtyHash(projectPath)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+
+	return Fingerprint{
+		Head:      head,
+		DirtyHash: dirtyHash,
+		System:    system,
+		Args:      append([]string(nil), args...),
+	}, nil
+}
+
+// computeDirtyHash calcola un hash delle modifiche locali: diff rispetto a HEAD
+// e contenuto dei file non tracciati. Restituisce una stringa vuota se il working tree è pulito.
+func computeDirtyHash(projectPath string) (string, error) {
+	status, err := exec.RunWithOutput("git", "-C", projectPath, "status", "--porcelain", "--untracked-files=all", ".")
+	if err != nil {
+		return "", fmt.Errorf("impossibile leggere lo stato del working tree: %w", err)
+	}
+	if status == "" {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, status)
+
+	diff, err := exec.RunWithOutput("git", "-C", projectPath, "diff", "HEAD", "--binary", "--", ".")
+	if err != nil {
+		return "", fmt.Errorf("impossibile leggere le modifiche locali: %w", err)
+	}
+	_, _ = io.WriteString(hash, diff)
+
+	untracked, err := exec.RunWithOutput("git", "-C", projectPath, "ls-files", "--others", "--exclude-standard", "--", ".")
+	if err != nil {
+		return "", fmt.Errorf("impossibile elencare i file non tracciati: %w", err)
+	}
+	for _, file := range strings.Split(untracked, "\n") {
+		if file == "" {
+			continue
+		}
+		_, _ = io.WriteString(hash, file)
+		if content, err := os.ReadFile(filepath.Join(projectPath, file)); err == nil {
+			_, _ = hash.Write(content)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Compare confronta il fingerprint corrente con quello dell'ultima build riuscita.
+// Restituisce true se il progetto è cambiato, insieme al motivo in forma leggibile.
+func (f Fingerprint) Compare(previous Fingerprint, found bool) (bool, string) {
+	switch {
+	case !found:
+		return true, "nessuna build riuscita registrata"
+	case f.Head != previous.Head:
+		return true, fmt.Sprintf("commit cambiato (%s → %s)", shortHash(previous.Head), shortHash(f.Head))
+	case f.DirtyHash != previous.DirtyHash && f.DirtyHash == "":
+		return true, "modifiche locali annullate o committate"
+	case f.DirtyHash != previous.DirtyHash:
+		return true, "modifiche locali non committate"
+	case previous.System != "" && f.System != previous.System: // Le build registrate senza build system erano Maven
+		return true, fmt.Sprintf("build system cambiato (%s → %s)", previous.System, f.System)
+	case !slices.Equal(f.Args, previous.Args):
+		return true, "argomenti della build cambiati"
+	default:
+		return false, fmt.Sprintf("invariato dalla build del %s (commit %s)",
+			previous.BuiltAt.Format("02/01/2006 15:04"), shortHash(previous.Head))
+	}
+}
+
+// shortHash abbrevia un hash di commit come fa git
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// Store contiene i fingerprint delle ultime build riuscite di ogni progetto
+type Store struct {
+	Projects map[string]Fingerprint `json:"projects"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// Load carica i fingerprint salvati nella directory indicata.
+// Se il file non esiste restituisce uno store vuoto.
+func Load(dir string) (*Store, error) {
+	store := &Store{
+		Projects: make(map[string]Fingerprint),
+		path:     filepath.Join(dir, StoreFileName),
+	}
+
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("impossibile leggere i fingerprint dei progetti: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("file dei fingerprint non valido: %w", err)
+	}
+	if store.Projects == nil {
+		store.Projects = make(map[string]Fingerprint)
+	}
+	return store, nil
+}
+
+// Get restituisce il fingerprint dell'ultima build riuscita del progetto
+func (s *Store) Get(projectName string) (Fingerprint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fp, found := s.Projects[projectName]
+	return fp, found
+}
+
+// CompareDependencies verifica che l'ultima build riuscita del progetto sia successiva a quella
+// di ogni sua dipendenza. Restituisce true se una dipendenza non ha build registrate o è stata
+// ricompilata dopo il progetto (es. build del progetto fallita o saltata), insieme al motivo.
+func (s *Store) CompareDependencies(projectName string, deps []string) (bool, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	built := s.Projects[projectName].BuiltAt
+	for _, dep := range deps {
+		depFp, found := s.Projects[dep]
+		switch {
+		case !found:
+			return true, fmt.Sprintf("dipendenza %s senza build riuscita registrata", dep)
+		case depFp.BuiltAt.After(built):
+			return true, fmt.Sprintf("dipendenza %s ricompilata il %s, dopo l'ultima build",
+				dep, depFp.BuiltAt.Format("02/01/2006 15:04"))
+		}
+	}
+	return false, ""
+}
+
+// Record registra il fingerprint di una build riuscita e salva subito lo store.
+// È sicuro per l'uso concorrente.
+func (s *Store) Record(projectName string, fp Fingerprint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fp.BuiltAt = time.Now()
+	s.Projects[projectName] = fp
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("impossibile serializzare i fingerprint: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, filePermissions); err != nil {
+		return fmt.Errorf("impossibile salvare i fingerprint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package fingerprint
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCompare verifica ogni motivo per cui un progetto risulta cambiato o invariato
+func TestCompare(t *testing.T) {
+	previous := Fingerprint{
+		Head:    "0123456789abcdef",
+		System:  "maven",
+		Args:    []string{"clean", "install"},
+		BuiltAt: time.Date(2025, 3, 1, 10, 30, 0, 0, time.Local),
+	}
+	dirty := previous
+	dirty.DirtyHash = "d1"
+	legacy := previous
+	legacy.System = ""
+
+	tests := []struct {
+		name     string
+		current  Fingerprint
+		previous Fingerprint
+		found    bool
+		changed  bool
+		reason   string
+	}{
+		{"nessuna build", previous, Fingerprint{}, false, true, "nessuna build riuscita registrata"},
+		{"commit cambiato", Fingerprint{Head: "fedcba9876543210", System: previous.System, Args: previous.Args}, previous, true, true, "commit cambiato (0123456 → fedcba9)"},
+		{"modifiche locali", dirty, previous, true, true, "modifiche locali non committate"},
+		{"modifiche locali diverse", Fingerprint{Head: previous.Head, DirtyHash: "d2", System: previous.System, Args: previous.Args}, dirty, true, true, "modifiche locali non committate"},
+		{"da modificato a pulito", previous, dirty, true, true, "modifiche locali annullate o committate"},
+		{"argomenti cambiati", Fingerprint{Head: previous.Head, System: previous.System, Args: []string{"install"}}, previous, true, true, "argomenti della build cambiati"},
+		{"build system cambiato", Fingerprint{Head: previous.Head, System: "gradle", Args: previous.Args}, previous, true, true, "build system cambiato (maven → gradle)"},
+		{"invariato", Fingerprint{Head: previous.Head, System: previous.System, Args: previous.Args}, previous, true, false, "invariato dalla build del 01/03/2025 10:30 (commit 0123456)"},
+		{"invariato con modifiche", dirty, dirty, true, false, "invariato dalla build del 01/03/2025 10:30 (commit 0123456)"},
+		{"registrato senza build system", previous, legacy, true, false, "invariato dalla build del 01/03/2025 10:30 (commit 0123456)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, reason := tt.current.Compare(tt.previous, tt.found)
+			if changed != tt.changed || reason != tt.reason {
+				t.Errorf("Compare() = (%v, %q), atteso (%v, %q)", changed, reason, tt.changed, tt.reason)
+			}
+		})
+	}
+}
+
+// TestStoreRecord verifica che i fingerprint registrati vengano salvati e ricaricati
+func TestStoreRecord(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() errore inatteso: %v", err)
+	}
+	if err := store.Record("core", Fingerprint{Head: "abc", Args: []string{"install"}}); err != nil {
+		t.Fatalf("Record() errore inatteso: %v", err)
+	}
+
+	reloaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() errore inatteso: %v", err)
+	}
+	fp, found := reloaded.Get("core")
+	if !found || fp.Head != "abc" || fp.BuiltAt.IsZero() {
+		t.Errorf("fingerprint ricaricato = %+v (trovato %v), atteso head abc con data della build", fp, found)
+	}
+}
+
+// TestCompareDependencies verifica che un progetto risulti obsoleto se una dipendenza è stata
+// ricompilata dopo di lui, ad esempio perché la sua build è fallita dopo quella della dipendenza
+func TestCompareDependencies(t *testing.T) {
+	builtAt := time.Date(2025, 3, 1, 10, 30, 0, 0, time.Local)
+	store := &Store{Projects: map[string]Fingerprint{
+		"core":    {Head: "c1", BuiltAt: builtAt.Add(time.Hour)}, // Ricompilato, poi la build di app è fallita
+		"app":     {Head: "a1", BuiltAt: builtAt},
+		"tools":   {Head: "t1", BuiltAt: builtAt.Add(-time.Hour)},
+		"service": {Head: "s1", BuiltAt: builtAt.Add(2 * time.Hour)},
+	}}
+
+	tests := []struct {
+		name    string
+		project string
+		deps    []string
+		stale   bool
+		reason  string
+	}{
+		{"dipendenza ricompilata dopo la build fallita", "app", []string{"tools", "core"}, true, "dipendenza core ricompilata il 01/03/2025 11:30, dopo l'ultima build"},
+		{"dipendenza senza build registrata", "app", []string{"legacy"}, true, "dipendenza legacy senza build riuscita registrata"},
+		{"dipendenze più vecchie", "service", []string{"core", "tools"}, false, ""},
+		{"nessuna dipendenza", "tools", nil, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stale, reason := store.CompareDependencies(tt.project, tt.deps)
+			if stale != tt.stale || reason != tt.reason {
+				t.Errorf("CompareDependencies() = (%v, %q), atteso (%v, %q)", stale, reason, tt.stale, tt.reason)
+			}
+		})
+	}
+}
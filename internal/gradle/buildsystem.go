@@ -0,0 +1,81 @@
+package gradle
+
+import (
+	"runtime"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+// Name è il nome del build system Gradle
+const Name = "gradle"
+
+// systemGradle è l'eseguibile Gradle cercato nel PATH
+const systemGradle = "gradle"
+
+// BuildSystem implementa buildsystem.BuildSystem per i progetti Gradle (Groovy e Kotlin DSL)
+type BuildSystem struct{}
+
+// Name implementa buildsystem.BuildSystem
+func (BuildSystem) Name() string {
+	return Name
+}
+
+// Detect implementa buildsystem.BuildSystem: una build Gradle ha un settings.gradle o un build.gradle
+func (BuildSystem) Detect(dir string) bool {
+	return hasAnyFile(dir, settingsFiles) || hasAnyFile(dir, buildFiles)
+}
+
+// ParseProject implementa buildsystem.BuildSystem
+func (BuildSystem) ParseProject(projectName, dir string) (*buildsystem.Project, error) {
+	return parseProject(projectName, dir)
+}
+
+// RegisterArtifacts implementa buildsystem.BuildSystem registrando il progetto root e i sottoprogetti
+func (BuildSystem) RegisterArtifacts(project *buildsystem.Project, registry *buildsystem.ArtifactRegistry) {
+	registry.Register(project.Identifier, project.Name)
+
+	b, err := loadBuild(project.Path)
+	if err != nil {
+		return // Build non leggibile: l'errore è già emerso durante l'analisi del progetto
+	}
+	for _, sub := range b.subprojects {
+		registry.Register(makeIdentifier(sub.group, sub.name), project.Name)
+	}
+}
+
+// BuildCommand implementa buildsystem.BuildSystem: esegue i task con il Gradle Wrapper del progetto
+// se presente, altrimenti con Gradle dal PATH. L'output è in modalità testuale semplice.
+func (BuildSystem) BuildCommand(dir string, tasks []string, opts buildsystem.BuildOptions) buildsystem.Command {
+	executable := systemGradle
+	if wrapper, found := firstFile(dir, []string{wrapperScript()}); found {
+		executable = wrapper
+	}
+
+	args := []string{"--console=plain"}
+	args = append(args, tasks...)
+	args = append(args, opts.Args...)
+
+	if opts.SkipTests {
+		args = append(args, "-x", "test")
+	}
+	return buildsystem.Command{Executable: executable, Args: args}
+}
+
+// OutputParser implementa buildsystem.BuildSystem riconoscendo i task Gradle come fasi della build
+func (BuildSystem) OutputParser() executor.OutputParser {
+	return &outputParser{pending: -1}
+}
+
+// UsesJDK implementa buildsystem.BuildSystem
+func (BuildSystem) UsesJDK() bool {
+	return true
+}
+
+// wrapperScript restituisce il nome dello script del Gradle Wrapper per il sistema operativo corrente
+func wrapperScript() string {
+	if runtime.GOOS == "windows" {
+		return "gradlew.bat"
+	}
+	return "gradlew"
+}
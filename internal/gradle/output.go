@@ -0,0 +1,149 @@
+package gradle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+// Pattern per il matching dell'output Gradle in modalità --console=plain
+var (
+	// Esempio: > Task :compileJava
+	// Esempio: > Task :core:test UP-TO-DATE
+	// Cattura: percorso del sottoprogetto (vuoto per il progetto root) e nome del task
+	taskPattern = regexp.MustCompile(`^> Task :(?:(\S+):)?([^:\s]+)(?:\s.*)?$`)
+
+	// Esempio: 5 tests completed, 1 failed, 2 skipped
+	testSummaryPattern = regexp.MustCompile(`^(\d+) tests? completed(?:, (\d+) failed)?(?:, (\d+) skipped)?`)
+
+	// Esempio: /src/main/java/com/example/App.java:12: error: cannot find symbol
+	javacErrorPattern = regexp.MustCompile(`^(\S+\.java):(\d+): error: (.+)$`)
+
+	// Esempio: e: file:///src/main/kotlin/App.kt:12:5 Unresolved reference: foo
+	kotlinErrorPattern = regexp.MustCompile(`^e: (?:file://)?(\S+?):(\d+):(\d+) (.+)$`)
+
+	// Esempio: AppTest > shouldWork() FAILED
+	testFailurePattern = regexp.MustCompile(`^(\S+) > (.+) FAILED$`)
+
+	// Esempio: > Could not find com.example:lib:1.0.
+	// Esempio: > Could not resolve all files for configuration ':compileClasspath'.
+	dependencyErrorPattern = regexp.MustCompile(`^(?:> )?(Could not (?:find|resolve) .+)$`)
+)
+
+// taskPhases associa i task Gradle più comuni alle fasi mostrate durante la build,
+// con gli stessi nomi delle fasi Maven equivalenti
+var taskPhases = map[string]string{
+	"clean":                "CLEAN",
+	"processResources":     "RESOURCES",
+	"compileJava":          "COMPILE",
+	"compileKotlin":        "COMPILE",
+	"compileGroovy":        "COMPILE",
+	"processTestResources": "TEST-RESOURCES",
+	"compileTestJava":      "TEST-COMPILE",
+	"compileTestKotlin":    "TEST-COMPILE",
+	"compileTestGroovy":    "TEST-COMPILE",
+	"test":                 "TEST",
+	"integrationTest":      "INTEGRATION-TEST",
+	"jar":                  "PACKAGE",
+	"war":                  "PACKAGE",
+	"bootJar":              "REPACKAGE",
+	"bootWar":              "REPACKAGE",
+	"publishToMavenLocal":  "INSTALL",
+	"publish":              "DEPLOY",
+}
+
+// outputParser implementa executor.OutputParser per l'output di Gradle
+type outputParser struct {
+	diagnostics executor.DiagnosticList
+	pending     int // Indice del test fallito in attesa del messaggio (-1 = nessuno)
+}
+
+// ParseLine implementa executor.OutputParser
+func (p *outputParser) ParseLine(line string) executor.OutputEvent {
+	line = executor.StripANSI(line)
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return executor.OutputEvent{}
+	}
+
+	// Il messaggio di un test fallito è la prima riga indentata successiva
+	if p.pending >= 0 {
+		pending := p.pending
+		p.pending = -1
+		if line != trimmed {
+			p.diagnostics.SetMessage(pending, trimmed)
+			return executor.OutputEvent{}
+		}
+	}
+
+	if matches := taskPattern.FindStringSubmatch(trimmed); matches != nil {
+		return executor.OutputEvent{Kind: executor.EventPhaseStarted, Phase: taskPhase(matches[1], matches[2])}
+	}
+
+	if matches := testSummaryPattern.FindStringSubmatch(trimmed); matches != nil {
+		run, _ := strconv.Atoi(matches[1])
+		failures, _ := strconv.Atoi(matches[2])
+		skipped, _ := strconv.Atoi(matches[3])
+		return executor.OutputEvent{
+			Kind:    executor.EventTestResults,
+			Results: executor.TestResults{Run: run, Failures: failures, Skipped: skipped},
+		}
+	}
+
+	p.collect(trimmed)
+	return executor.OutputEvent{}
+}
+
+// collect riconosce i problemi in una riga dell'output
+func (p *outputParser) collect(line string) {
+	if matches := javacErrorPattern.FindStringSubmatch(line); matches != nil {
+		lineNumber, _ := strconv.Atoi(matches[2])
+		p.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticCompilation, File: matches[1],
+			Line: lineNumber, Message: matches[3]})
+		return
+	}
+
+	if matches := kotlinErrorPattern.FindStringSubmatch(line); matches != nil {
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		p.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticCompilation, File: matches[1],
+			Line: lineNumber, Column: column, Message: matches[4]})
+		return
+	}
+
+	if matches := testFailurePattern.FindStringSubmatch(line); matches != nil {
+		test := fmt.Sprintf("%s.%s", matches[1], strings.TrimSuffix(matches[2], "()"))
+		if p.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticTest, Test: test}) {
+			p.pending = p.diagnostics.Len() - 1
+		}
+		return
+	}
+
+	if matches := dependencyErrorPattern.FindStringSubmatch(line); matches != nil {
+		p.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticDependency, Message: matches[1]})
+	}
+}
+
+// Diagnostics implementa executor.OutputParser
+func (p *outputParser) Diagnostics() []executor.Diagnostic {
+	return p.diagnostics.Items()
+}
+
+// taskPhase restituisce la fase corrispondente a un task del sottoprogetto indicato
+// (vuoto per il progetto root). I task non riconosciuti diventano una fase con il nome del task.
+func taskPhase(projectPath, task string) executor.Phase {
+	name, found := taskPhases[task]
+	if !found {
+		name = task
+	}
+
+	description := name
+	module := strings.ReplaceAll(projectPath, ":", "/")
+	if module != "" {
+		description = fmt.Sprintf("%s @ %s", name, module)
+	}
+	return executor.Phase{Name: name, Goal: task, Module: module, Description: description}
+}
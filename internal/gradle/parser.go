@@ -0,0 +1,391 @@
+// Package gradle fornisce funzionalità per l'analisi di progetti Gradle
+package gradle
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+)
+
+// File che identificano una build Gradle, nelle varianti Groovy e Kotlin DSL
+var (
+	settingsFiles = []string{"settings.gradle", "settings.gradle.kts"}
+	buildFiles    = []string{"build.gradle", "build.gradle.kts"}
+)
+
+// propertiesFile contiene le proprietà della build (es. group=com.example)
+const propertiesFile = "gradle.properties"
+
+// Pattern per il riconoscimento delle dichiarazioni negli script Gradle
+var (
+	// Commenti di riga e di blocco; "//" preceduto da ':' (es. negli URL) non è un commento
+	lineCommentPattern  = regexp.MustCompile(`(?m)(^|[^:])//.*$`)
+	blockCommentPattern = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	// rootProject.name = 'nome'
+	rootNamePattern = regexp.MustCompile(`rootProject\.name\s*=\s*['"]([^'"]+)['"]`)
+	// include 'a', ':b:c' oppure include("a", "b"), anche su più righe
+	includeCallPattern = regexp.MustCompile(`\binclude\s*\(([^)]*)\)`)
+	includeLinePattern = regexp.MustCompile(`(?m)\binclude\s+(['"][^\n]*)$`)
+	// includeBuild '../lib' oppure includeBuild("../lib")
+	includeBuildPattern = regexp.MustCompile(`\bincludeBuild\s*\(?\s*['"]([^'"]+)['"]`)
+	// group = 'com.example' oppure group 'com.example' all'inizio di una riga
+	groupPattern = regexp.MustCompile(`(?m)^\s*group\s*=?\s*['"]([^'"]+)['"]`)
+	// Dipendenze in notazione stringa o project(): configurazione, platform() opzionale e valore
+	dependencyPattern = regexp.MustCompile(`\b(\w+)\s*\(?\s*(?:(platform|enforcedPlatform)\s*\(\s*)?(?:(project)\s*\(\s*(?:path\s*[:=]\s*)?)?['"]([^'"\s]+)['"]`)
+	// Dipendenze in notazione mappa: group: 'g', name: 'a'
+	dependencyMapPattern = regexp.MustCompile(`\b(\w+)\s*\(?\s*group\s*:\s*['"]([^'"]+)['"]\s*,\s*name\s*:\s*['"]([^'"]+)['"]`)
+	// Elementi stringa tra apici
+	quotedPattern = regexp.MustCompile(`['"]([^'"]+)['"]`)
+	// Riferimenti a proprietà: $nome o ${nome}
+	propertyReferencePattern = regexp.MustCompile(`\$\{([\w.]+)\}|\$([\w.]+)`)
+)
+
+// dependencyConfigurations sono le configurazioni Gradle che dichiarano dipendenze tra artifact
+var dependencyConfigurations = map[string]bool{
+	"api": true, "implementation": true, "compileOnly": true, "compileOnlyApi": true, "runtimeOnly": true,
+	"annotationProcessor": true, "kapt": true, "ksp": true, "compile": true, "runtime": true,
+	"testImplementation": true, "testCompileOnly": true, "testRuntimeOnly": true, "testCompile": true,
+	"classpath": true,
+}
+
+// dependencyConfigurationSuffixes riconoscono le configurazioni dei source set (es. integrationTestImplementation)
+var dependencyConfigurationSuffixes = []string{"Implementation", "Api", "CompileOnly", "RuntimeOnly", "AnnotationProcessor"}
+
+// build rappresenta una build Gradle: il progetto root con i suoi sottoprogetti
+type build struct {
+	dir            string            // Directory della build
+	name           string            // Nome del progetto root (rootProject.name o nome della directory)
+	group          string            // Group del progetto root
+	settingsPath   string            // settings.gradle(.kts), vuoto se assente
+	subprojects    []subproject      // Sottoprogetti dichiarati con include
+	includedBuilds []string          // Directory delle build incluse con includeBuild
+	properties     map[string]string // Proprietà di gradle.properties
+}
+
+// subproject è un sottoprogetto di una build Gradle multi-progetto
+type subproject struct {
+	path  string // Percorso Gradle (es. ":services:api")
+	name  string // Nome del sottoprogetto (ultimo elemento del percorso)
+	dir   string // Directory del sottoprogetto
+	group string // Group del sottoprogetto (quello della build se non dichiarato)
+}
+
+// parseProject analizza la build Gradle nella directory dir: identificatore group:nome del progetto root,
+// dipendenze dichiarate negli script di build di tutti i sottoprogetti e build incluse
+func parseProject(projectName, dir string) (*buildsystem.Project, error) {
+	b, err := loadBuild(dir)
+	if err != nil {
+		return nil, fmt.Errorf("errore analisi progetto %s: %w", projectName, err)
+	}
+
+	project := &buildsystem.Project{
+		Name:         projectName,
+		Path:         dir,
+		Identifier:   makeIdentifier(b.group, b.name),
+		Dependencies: make([]buildsystem.Dependency, 0),
+	}
+
+	// Dipendenze del progetto root e dei sottoprogetti; project(':x') identifica un sottoprogetto della build
+	dirs := []string{dir}
+	for _, sub := range b.subprojects {
+		dirs = append(dirs, sub.dir)
+	}
+	for _, projectDir := range dirs {
+		buildPath, found := firstFile(projectDir, buildFiles)
+		if !found {
+			continue
+		}
+		dependencies, warnings, err := extractDependencies(buildPath, b)
+		if err != nil {
+			return nil, fmt.Errorf("errore analisi progetto %s: %w", projectName, err)
+		}
+		project.Dependencies = append(project.Dependencies, dependencies...)
+		project.Warnings = append(project.Warnings, warnings...)
+	}
+
+	// Le build incluse vengono compilate da Gradle insieme al progetto, quindi vanno ordinate prima
+	for _, includedDir := range b.includedBuilds {
+		included, err := loadBuild(includedDir)
+		if err != nil {
+			project.Warnings = append(project.Warnings, fmt.Sprintf("%s: build inclusa non leggibile: %v", b.settingsPath, err))
+			continue
+		}
+		project.Dependencies = append(project.Dependencies, buildsystem.Dependency{
+			Identifier: makeIdentifier(included.group, included.name),
+			Source:     b.settingsPath,
+			Kind:       buildsystem.KindDependency,
+		})
+	}
+
+	return project, nil
+}
+
+// loadBuild legge settings.gradle, lo script di build del progetto root e gradle.properties.
+// Group e nomi non dichiarati vengono ricavati come fa Gradle: nome della directory, group del root.
+func loadBuild(dir string) (*build, error) {
+	b := &build{dir: dir, name: filepath.Base(dir)}
+
+	properties, err := readProperties(filepath.Join(dir, propertiesFile))
+	if err != nil {
+		return nil, err
+	}
+	b.properties = properties
+
+	if settingsPath, found := firstFile(dir, settingsFiles); found {
+		b.settingsPath = settingsPath
+		script, err := readScript(settingsPath)
+		if err != nil {
+			return nil, err
+		}
+		if match := rootNamePattern.FindStringSubmatch(script); match != nil {
+			b.name = match[1]
+		}
+		for _, projectPath := range includedProjects(script) {
+			b.subprojects = append(b.subprojects, newSubproject(dir, projectPath))
+		}
+		for _, match := range includeBuildPattern.FindAllStringSubmatch(script, -1) {
+			b.includedBuilds = append(b.includedBuilds, filepath.Join(dir, filepath.FromSlash(match[1])))
+		}
+	}
+
+	b.group = properties["group"]
+	if buildPath, found := firstFile(dir, buildFiles); found {
+		group, err := declaredGroup(buildPath)
+		if err != nil {
+			return nil, err
+		}
+		if group != "" {
+			b.group = group
+		}
+	}
+
+	// I sottoprogetti senza group ereditano quello del progetto root (tipicamente da allprojects {})
+	for i, sub := range b.subprojects {
+		b.subprojects[i].group = b.group
+		if buildPath, found := firstFile(sub.dir, buildFiles); found {
+			if group, err := declaredGroup(buildPath); err == nil && group != "" {
+				b.subprojects[i].group = group
+			}
+		}
+	}
+
+	if b.settingsPath == "" && !hasAnyFile(dir, buildFiles) {
+		return nil, fmt.Errorf("nessuno script Gradle trovato in %s", dir)
+	}
+	return b, nil
+}
+
+// includedProjects restituisce i percorsi dei sottoprogetti dichiarati con include nel settings.gradle
+func includedProjects(script string) []string {
+	arguments := make([]string, 0)
+	for _, match := range includeCallPattern.FindAllStringSubmatch(script, -1) {
+		arguments = append(arguments, match[1])
+	}
+	for _, match := range includeLinePattern.FindAllStringSubmatch(script, -1) {
+		arguments = append(arguments, match[1])
+	}
+
+	paths := make([]string, 0)
+	for _, argument := range arguments {
+		for _, quoted := range quotedPattern.FindAllStringSubmatch(argument, -1) {
+			paths = append(paths, quoted[1])
+		}
+	}
+	return paths
+}
+
+// newSubproject crea un sottoprogetto dal suo percorso Gradle: ":services:api" si trova in services/api
+func newSubproject(rootDir, projectPath string) subproject {
+	relPath := strings.ReplaceAll(strings.TrimPrefix(projectPath, ":"), ":", "/")
+	return subproject{
+		path: projectPath,
+		name: path.Base(relPath),
+		dir:  filepath.Join(rootDir, filepath.FromSlash(relPath)),
+	}
+}
+
+// declaredGroup restituisce il group dichiarato nello script di build (vuoto se assente)
+func declaredGroup(buildPath string) (string, error) {
+	script, err := readScript(buildPath)
+	if err != nil {
+		return "", err
+	}
+	if match := groupPattern.FindStringSubmatch(script); match != nil {
+		return match[1], nil
+	}
+	return "", nil
+}
+
+// extractDependencies estrae le dipendenze dichiarate in uno script di build, annotando il file che le dichiara.
+// Le dipendenze di classpath dello script (buildscript) sono plugin, quelle in platform() BOM importati.
+// Restituisce anche gli avvisi per le proprietà che non è stato possibile risolvere.
+func extractDependencies(buildPath string, b *build) ([]buildsystem.Dependency, []string, error) {
+	script, err := readScript(buildPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dependencies := make([]buildsystem.Dependency, 0)
+	warnings := make([]string, 0)
+	add := func(configuration, group, name string, platform bool) {
+		group, unresolved := b.interpolate(group)
+		for _, property := range unresolved {
+			warning := fmt.Sprintf("%s: proprietà '%s' non risolta", buildPath, property)
+			if !slices.Contains(warnings, warning) {
+				warnings = append(warnings, warning)
+			}
+		}
+
+		kind := buildsystem.KindDependency
+		switch {
+		case configuration == "classpath":
+			kind = buildsystem.KindPlugin
+		case platform:
+			kind = buildsystem.KindImport
+		}
+		dependencies = append(dependencies, buildsystem.Dependency{
+			Identifier: makeIdentifier(group, name),
+			Source:     buildPath,
+			Kind:       kind,
+		})
+	}
+
+	for _, match := range dependencyPattern.FindAllStringSubmatch(script, -1) {
+		configuration, platform, isProject, value := match[1], match[2] != "", match[3] != "", match[4]
+		if !isDependencyConfiguration(configuration) {
+			continue
+		}
+		if isProject {
+			sub := newSubproject(b.dir, value)
+			add(configuration, b.subprojectGroup(value), sub.name, platform)
+			continue
+		}
+		// Coordinate group:artifact[:version]; la versione non serve per l'ordinamento
+		parts := strings.Split(value, ":")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		add(configuration, parts[0], parts[1], platform)
+	}
+	for _, match := range dependencyMapPattern.FindAllStringSubmatch(script, -1) {
+		if isDependencyConfiguration(match[1]) {
+			add(match[1], match[2], match[3], false)
+		}
+	}
+
+	return dependencies, warnings, nil
+}
+
+// subprojectGroup restituisce il group del sottoprogetto con il percorso Gradle indicato
+func (b *build) subprojectGroup(projectPath string) string {
+	target := strings.TrimPrefix(projectPath, ":")
+	for _, sub := range b.subprojects {
+		if strings.TrimPrefix(sub.path, ":") == target {
+			return sub.group
+		}
+	}
+	return b.group
+}
+
+// interpolate risolve i riferimenti $nome e ${nome} con group, version e le proprietà di gradle.properties.
+// Restituisce il valore risolto e i nomi delle proprietà non risolte.
+func (b *build) interpolate(value string) (string, []string) {
+	unresolved := make([]string, 0)
+	resolved := propertyReferencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := strings.Trim(match, "${}")
+		name = strings.TrimPrefix(name, "project.")
+		if name == "group" && b.group != "" {
+			return b.group
+		}
+		if property, found := b.properties[name]; found {
+			return property
+		}
+		unresolved = append(unresolved, name)
+		return match
+	})
+	return resolved, unresolved
+}
+
+// isDependencyConfiguration indica se il nome è una configurazione di dipendenze
+func isDependencyConfiguration(name string) bool {
+	if dependencyConfigurations[name] {
+		return true
+	}
+	for _, suffix := range dependencyConfigurationSuffixes {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readScript legge uno script Gradle rimuovendo i commenti
+func readScript(scriptPath string) (string, error) {
+	data, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return "", fmt.Errorf("impossibile leggere %s: %w", scriptPath, err)
+	}
+	script := blockCommentPattern.ReplaceAllString(string(data), "")
+	return lineCommentPattern.ReplaceAllString(script, "$1"), nil
+}
+
+// readProperties legge un file gradle.properties (chiave=valore); un file assente non è un errore
+func readProperties(propertiesPath string) (map[string]string, error) {
+	properties := make(map[string]string)
+	file, err := os.Open(propertiesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return properties, nil
+		}
+		return nil, fmt.Errorf("impossibile leggere %s: %w", propertiesPath, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			name, value, found = strings.Cut(line, ":")
+		}
+		if found {
+			properties[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("impossibile leggere %s: %w", propertiesPath, err)
+	}
+	return properties, nil
+}
+
+// firstFile restituisce il primo dei file indicati presente nella directory
+func firstFile(dir string, names []string) (string, bool) {
+	for _, name := range names {
+		filePath := filepath.Join(dir, name)
+		if info, err := os.Stat(filePath); err == nil && !info.IsDir() {
+			return filePath, true
+		}
+	}
+	return "", false
+}
+
+// hasAnyFile indica se la directory contiene almeno uno dei file indicati
+func hasAnyFile(dir string, names []string) bool {
+	_, found := firstFile(dir, names)
+	return found
+}
+
+// makeIdentifier crea l'identificatore di un artifact (group:nome), compatibile con le coordinate Maven
+func makeIdentifier(group, name string) string {
+	return fmt.Sprintf("%s:%s", group, name)
+}
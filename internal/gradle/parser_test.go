@@ -0,0 +1,196 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven"
+)
+
+// writeFile crea un file con il contenuto indicato, creando le directory mancanti
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestParseProject verifica nome, group, sottoprogetti e dipendenze di una build multi-progetto
+func TestParseProject(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "orders")
+	writeFile(t, filepath.Join(dir, "settings.gradle.kts"), `
+rootProject.name = "orders-service"
+include(
+    ":api",
+    ":persistence:jpa"
+)
+includeBuild("../build-logic")
+`)
+	writeFile(t, filepath.Join(dir, "gradle.properties"), "commonsGroup=com.example.commons\n")
+	writeFile(t, filepath.Join(dir, "build.gradle.kts"), `
+allprojects {
+    group = "com.example.orders"
+}
+`)
+	writeFile(t, filepath.Join(dir, "api", "build.gradle.kts"), `
+dependencies {
+    implementation(project(":persistence:jpa"))
+    implementation("${commonsGroup}:commons-core:1.0")
+    api(platform("com.example:platform-bom:2.0"))
+    // implementation("com.example:commented-out:1.0")
+    testImplementation("org.junit.jupiter:junit-jupiter:5.10.0")
+}
+`)
+	writeFile(t, filepath.Join(dir, "persistence", "jpa", "build.gradle"), `
+dependencies {
+    implementation group: 'com.example', name: 'legacy-model', version: '3.1'
+    integrationTestImplementation "com.example:test-fixtures:1.0"
+}
+`)
+	writeFile(t, filepath.Join(root, "build-logic", "settings.gradle"), "rootProject.name = 'build-logic'\n")
+	writeFile(t, filepath.Join(root, "build-logic", "build.gradle"), "group 'com.example.build'\n")
+
+	project, err := BuildSystem{}.ParseProject("orders", dir)
+	if err != nil {
+		t.Fatalf("ParseProject() errore inatteso: %v", err)
+	}
+
+	if project.Identifier != "com.example.orders:orders-service" {
+		t.Errorf("Identifier = %s, atteso com.example.orders:orders-service", project.Identifier)
+	}
+
+	got := make(map[string]buildsystem.DependencyKind)
+	for _, dep := range project.Dependencies {
+		got[dep.Identifier] = dep.Kind
+	}
+	expected := map[string]buildsystem.DependencyKind{
+		"com.example.orders:jpa":           buildsystem.KindDependency,
+		"com.example.commons:commons-core": buildsystem.KindDependency,
+		"com.example:platform-bom":         buildsystem.KindImport,
+		"org.junit.jupiter:junit-jupiter":  buildsystem.KindDependency,
+		"com.example:legacy-model":         buildsystem.KindDependency,
+		"com.example:test-fixtures":        buildsystem.KindDependency,
+		"com.example.build:build-logic":    buildsystem.KindDependency,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Dipendenze = %v, attese %v", got, expected)
+	}
+
+	registry := buildsystem.NewArtifactRegistry()
+	BuildSystem{}.RegisterArtifacts(project, registry)
+	if name, found := registry.Lookup("com.example.orders:api"); !found || name != "orders" {
+		t.Errorf("il sottoprogetto :api deve essere registrato come 'orders', trovato %q", name)
+	}
+}
+
+// TestAnalyzeMixedProjects verifica che progetti Maven e Gradle vengano ordinati in un unico grafo
+func TestAnalyzeMixedProjects(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "core", "pom.xml"), `<project>
+  <groupId>com.example</groupId>
+  <artifactId>core</artifactId>
+</project>`)
+	writeFile(t, filepath.Join(root, "service", "settings.gradle"), "rootProject.name = 'service'\n")
+	writeFile(t, filepath.Join(root, "service", "build.gradle"), `
+group = 'com.example'
+dependencies {
+    implementation 'com.example:core:1.0.0'
+}
+`)
+	writeFile(t, filepath.Join(root, "app", "pom.xml"), `<project>
+  <groupId>com.example</groupId>
+  <artifactId>app</artifactId>
+  <dependencies>
+    <dependency><groupId>com.example</groupId><artifactId>service</artifactId></dependency>
+  </dependencies>
+</project>`)
+
+	if _, err := maven.AnalyzeDependencies([]string{"app", "core", "service"}, root); err == nil {
+		t.Fatal("senza il build system Gradle l'analisi del progetto Gradle deve fallire")
+	}
+
+	analysis, err := buildsystem.Analyze([]string{"app", "core", "service"}, buildsystem.AnalyzeOptions{
+		ProjectDir: func(projectName string) string {
+			return filepath.Join(root, projectName)
+		},
+		Systems: []buildsystem.BuildSystem{maven.BuildSystem{}, BuildSystem{}},
+	})
+	if err != nil {
+		t.Fatalf("Analyze() errore inatteso: %v", err)
+	}
+
+	sorted, err := analysis.TopologicalSort(nil)
+	if err != nil {
+		t.Fatalf("TopologicalSort() errore inatteso: %v", err)
+	}
+	if expected := []string{"core", "service", "app"}; !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("ordine = %v, atteso %v", sorted, expected)
+	}
+}
+
+// TestBuildCommand verifica l'uso del Gradle Wrapper e l'esclusione dei test
+func TestBuildCommand(t *testing.T) {
+	dir := t.TempDir()
+	command := BuildSystem{}.BuildCommand(dir, []string{"clean", "build"}, buildsystem.BuildOptions{SkipTests: true})
+	if command.Executable != "gradle" {
+		t.Errorf("senza wrapper l'eseguibile deve essere gradle, trovato %s", command.Executable)
+	}
+	if expected := []string{"--console=plain", "clean", "build", "-x", "test"}; !reflect.DeepEqual(command.Args, expected) {
+		t.Errorf("args = %v, attesi %v", command.Args, expected)
+	}
+
+	writeFile(t, filepath.Join(dir, wrapperScript()), "#!/bin/sh\n")
+	if command := (BuildSystem{}).BuildCommand(dir, nil, buildsystem.BuildOptions{}); command.Executable != filepath.Join(dir, wrapperScript()) {
+		t.Errorf("con il wrapper l'eseguibile deve essere %s, trovato %s", wrapperScript(), command.Executable)
+	}
+}
+
+// TestOutputParser verifica il riconoscimento di task, risultati dei test e problemi nell'output Gradle
+func TestOutputParser(t *testing.T) {
+	parser := BuildSystem{}.OutputParser()
+
+	tests := []struct {
+		line, expected string
+	}{
+		{"> Task :compileJava", "COMPILE"},
+		{"> Task :core:test UP-TO-DATE", "TEST @ core"},
+		{"> Task :services:api:generateClient", "generateClient @ services/api"},
+	}
+	for _, tt := range tests {
+		event := parser.ParseLine(tt.line)
+		if event.Kind != executor.EventPhaseStarted || event.Phase.Description != tt.expected {
+			t.Errorf("%s: atteso '%s', ottenuto %+v", tt.line, tt.expected, event)
+		}
+	}
+
+	output := []string{
+		"/src/main/java/com/example/App.java:12: error: cannot find symbol",
+		"AppTest > shouldWork() FAILED",
+		"    org.opentest4j.AssertionFailedError at AppTest.java:10",
+		"> Could not find com.example:lib:1.0.",
+	}
+	for _, line := range output {
+		parser.ParseLine(line)
+	}
+	if event := parser.ParseLine("5 tests completed, 1 failed, 2 skipped"); event.Kind != executor.EventTestResults ||
+		event.Results != (executor.TestResults{Run: 5, Failures: 1, Skipped: 2}) {
+		t.Errorf("riepilogo dei test non riconosciuto: %+v", event)
+	}
+
+	expected := []executor.Diagnostic{
+		{Kind: executor.DiagnosticCompilation, File: "/src/main/java/com/example/App.java", Line: 12, Message: "cannot find symbol"},
+		{Kind: executor.DiagnosticTest, Test: "AppTest.shouldWork", Message: "org.opentest4j.AssertionFailedError at AppTest.java:10"},
+		{Kind: executor.DiagnosticDependency, Message: "Could not find com.example:lib:1.0."},
+	}
+	if diagnostics := parser.Diagnostics(); !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Diagnostiche errate:\natteso  %+v\nottenuto %+v", expected, diagnostics)
+	}
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Upstream restituisce i nodi indicati e tutti quelli da cui dipendono transitivamente,
+// in ordine alfabetico. I nodi non presenti nel grafo vengono ignorati.
+func (g DependencyGraph) Upstream(nodes ...string) []string {
+	return g.closure(nodes, func(node string) []string {
+		return g[node]
+	})
+}
+
+// Downstream restituisce i nodi indicati e tutti quelli che dipendono transitivamente da essi,
+// in ordine alfabetico. I nodi non presenti nel grafo vengono ignorati.
+func (g DependencyGraph) Downstream(nodes ...string) []string {
+	_, reverseGraph := g.inDegreeAndReverse()
+	return g.closure(nodes, func(node string) []string {
+		return reverseGraph[node]
+	})
+}
+
+// Subgraph restituisce il sottografo indotto dai nodi indicati:
+// gli archi verso nodi esclusi vengono rimossi
+func (g DependencyGraph) Subgraph(nodes []string) DependencyGraph {
+	included := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if _, exists := g[node]; exists {
+			included[node] = true
+		}
+	}
+
+	sub := NewDependencyGraph()
+	for node := range included {
+		deps := make([]string, 0)
+		for _, dep := range g[node] {
+			if included[dep] {
+				deps = append(deps, dep)
+			}
+		}
+		sub.AddNode(node, deps)
+	}
+	return sub
+}
+
+// Restrict restringe il grafo ai nodi indicati in upstream con le loro dipendenze transitive
+// e ai nodi indicati in downstream con tutti i nodi che dipendono da essi.
+// Se entrambe le liste sono vuote restituisce il grafo invariato;
+// restituisce un errore se un nodo indicato non fa parte del grafo.
+func (g DependencyGraph) Restrict(upstream, downstream []string) (DependencyGraph, error) {
+	if len(upstream) == 0 && len(downstream) == 0 {
+		return g, nil
+	}
+
+	for _, list := range [][]string{upstream, downstream} {
+		for _, node := range list {
+			if _, exists := g[node]; !exists {
+				return nil, fmt.Errorf("il progetto '%s' non è tra i progetti selezionati", node)
+			}
+		}
+	}
+
+	nodes := g.Upstream(upstream...)
+	nodes = append(nodes, g.Downstream(downstream...)...)
+	return g.Subgraph(nodes), nil
+}
+
+// closure visita il grafo a partire dai nodi indicati seguendo gli archi restituiti da next
+func (g DependencyGraph) closure(start []string, next func(node string) []string) []string {
+	visited := make(map[string]bool)
+	stack := make([]string, 0, len(start))
+	for _, node := range start {
+		if _, exists := g[node]; exists {
+			stack = append(stack, node)
+		}
+	}
+
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[node] {
+			continue
+		}
+		visited[node] = true
+
+		for _, neighbour := range next(node) {
+			if _, exists := g[neighbour]; exists && !visited[neighbour] {
+				stack = append(stack, neighbour)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(visited))
+	for node := range visited {
+		result = append(result, node)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"sort"
+	"time"
+)
+
+// CriticalPath restituisce la catena di dipendenze con la durata complessiva maggiore,
+// dalla radice al nodo finale, e la sua durata. È il tempo minimo di esecuzione del grafo
+// con concorrenza illimitata. I nodi assenti da durations hanno durata zero.
+// Restituisce un errore se il grafo contiene cicli.
+func (g DependencyGraph) CriticalPath(durations map[string]time.Duration) ([]string, time.Duration, error) {
+	sorted, err := g.TopologicalSort()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// finish[n] è la durata della catena più lunga che termina con n; previous[n] il nodo precedente
+	finish := make(map[string]time.Duration, len(g))
+	previous := make(map[string]string, len(g))
+	last := ""
+	for _, node := range sorted {
+		var start time.Duration
+		for _, dep := range g[node] {
+			if finish[dep] > start || (finish[dep] == start && previous[node] == "") {
+				start = finish[dep]
+				previous[node] = dep
+			}
+		}
+		finish[node] = start + durations[node]
+		if last == "" || finish[node] > finish[last] {
+			last = node
+		}
+	}
+
+	if last == "" {
+		return nil, 0, nil
+	}
+
+	path := []string{last}
+	for node := previous[last]; node != ""; node = previous[node] {
+		path = append([]string{node}, path...)
+	}
+	return path, finish[last], nil
+}
+
+// EstimateSchedule stima la durata dell'esecuzione del grafo con Schedule e jobs esecuzioni
+// concorrenti, simulando lo scheduler con le durate indicate (i nodi assenti hanno durata zero).
+// Restituisce un errore se il grafo contiene cicli.
+func (g DependencyGraph) EstimateSchedule(jobs int, priority []string, durations map[string]time.Duration) (time.Duration, error) {
+	if _, err := g.TopologicalSort(); err != nil {
+		return 0, err
+	}
+
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	inDegree, reverseGraph := g.inDegreeAndReverse()
+	order := NewOrdering(priority)
+
+	ready := make([]string, 0)
+	for node, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, node)
+		}
+	}
+	order.Sort(ready)
+
+	type running struct {
+		node string
+		end  time.Duration
+	}
+	active := make([]running, 0, jobs)
+	var now time.Duration
+
+	for len(ready) > 0 || len(active) > 0 {
+		for len(active) < jobs && len(ready) > 0 {
+			active = append(active, running{node: ready[0], end: now + durations[ready[0]]})
+			ready = ready[1:]
+		}
+
+		// Termina il nodo che finisce per primo (a parità di tempo, nell'ordine di avvio)
+		sort.SliceStable(active, func(i, j int) bool { return active[i].end < active[j].end })
+		done := active[0]
+		active = active[1:]
+		now = done.end
+
+		for _, dependent := range reverseGraph[done.node] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+		order.Sort(ready)
+	}
+
+	return now, nil
+}
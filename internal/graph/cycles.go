@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Edge rappresenta un arco orientato del grafo: From dipende da To
+type Edge struct {
+	From string
+	To   string
+}
+
+// Cycle rappresenta una componente fortemente connessa del grafo che contiene almeno un ciclo
+type Cycle struct {
+	Nodes []string // Nodi della componente, in ordine alfabetico
+	Path  []string // Un ciclo che attraversa la componente (il primo nodo è ripetuto in fondo)
+}
+
+// Edges restituisce gli archi che compongono il percorso del ciclo
+func (c Cycle) Edges() []Edge {
+	edges := make([]Edge, 0, len(c.Path))
+	for i := 0; i+1 < len(c.Path); i++ {
+		edges = append(edges, Edge{From: c.Path[i], To: c.Path[i+1]})
+	}
+	return edges
+}
+
+// String restituisce il ciclo nel formato "a -> b -> c -> a"
+func (c Cycle) String() string {
+	return strings.Join(c.Path, " -> ")
+}
+
+// CycleError è l'errore restituito quando il grafo contiene dipendenze circolari.
+// Contiene un ciclo per ogni componente fortemente connessa coinvolta.
+type CycleError struct {
+	Cycles []Cycle
+}
+
+// Error restituisce la descrizione di tutti i cicli rilevati
+func (e *CycleError) Error() string {
+	paths := make([]string, len(e.Cycles))
+	for i, cycle := range e.Cycles {
+		paths[i] = cycle.String()
+	}
+	return fmt.Sprintf("dipendenze circolari rilevate nel grafo: %s", strings.Join(paths, "; "))
+}
+
+// Cycles restituisce tutte le componenti fortemente connesse del grafo che contengono un ciclo.
+// Usa l'algoritmo di Tarjan visitando i nodi in ordine alfabetico, così il risultato è deterministico.
+func (g DependencyGraph) Cycles() []Cycle {
+	nodes := g.Nodes()
+	sort.Strings(nodes)
+
+	index := 0
+	indices := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := make([]string, 0)
+	cycles := make([]Cycle, 0)
+
+	var strongConnect func(node string)
+	strongConnect = func(node string) {
+		indices[node] = index
+		lowLink[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, dep := range g[node] {
+			if _, exists := g[dep]; !exists {
+				continue // Dipendenza esterna al grafo
+			}
+			if _, visited := indices[dep]; !visited {
+				strongConnect(dep)
+				lowLink[node] = min(lowLink[node], lowLink[dep])
+			} else if onStack[dep] {
+				lowLink[node] = min(lowLink[node], indices[dep])
+			}
+		}
+
+		// Il nodo è la radice di una componente: estraila dallo stack
+		if lowLink[node] != indices[node] {
+			return
+		}
+
+		component := make([]string, 0)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+
+		if len(component) > 1 || g.hasSelfLoop(node) {
+			sort.Strings(component)
+			cycles = append(cycles, Cycle{
+				Nodes: component,
+				Path:  g.cyclePath(component),
+			})
+		}
+	}
+
+	for _, node := range nodes {
+		if _, visited := indices[node]; !visited {
+			strongConnect(node)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].Nodes[0] < cycles[j].Nodes[0]
+	})
+
+	return cycles
+}
+
+// hasSelfLoop verifica se un nodo dipende da se stesso
+func (g DependencyGraph) hasSelfLoop(node string) bool {
+	for _, dep := range g[node] {
+		if dep == node {
+			return true
+		}
+	}
+	return false
+}
+
+// cyclePath trova il ciclo più breve che parte dal primo nodo della componente e vi ritorna,
+// visitando in ampiezza solo i nodi della componente
+func (g DependencyGraph) cyclePath(component []string) []string {
+	start := component[0]
+	inComponent := make(map[string]bool, len(component))
+	for _, node := range component {
+		inComponent[node] = true
+	}
+
+	parent := make(map[string]string)
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		deps := append([]string(nil), g[current]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if !inComponent[dep] {
+				continue
+			}
+			if dep == start {
+				// Ricostruisci il percorso a ritroso fino al nodo di partenza
+				path := []string{start}
+				for node := current; node != start; node = parent[node] {
+					path = append(path, node)
+				}
+				path = append(path, start)
+				for i, j := 1, len(path)-2; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := parent[dep]; !seen {
+				parent[dep] = current
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	return []string{start, start}
+}
@@ -0,0 +1,275 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ExportOptions configura l'esportazione del grafo nei vari formati
+type ExportOptions struct {
+	EdgeLabels      func(edge Edge) []string // Etichette opzionali degli archi (es. tipo di dipendenza)
+	HighlightCycles bool                     // Evidenzia nodi e archi che fanno parte di un ciclo
+}
+
+// labels restituisce le etichette di un arco, se configurate
+func (o ExportOptions) labels(edge Edge) []string {
+	if o.EdgeLabels == nil {
+		return nil
+	}
+	return o.EdgeLabels(edge)
+}
+
+// cycleMembers restituisce l'insieme dei nodi e degli archi che fanno parte di un ciclo
+func (g DependencyGraph) cycleMembers() (map[string]bool, map[Edge]bool) {
+	nodes := make(map[string]bool)
+	edges := make(map[Edge]bool)
+
+	for _, cycle := range g.Cycles() {
+		inComponent := make(map[string]bool, len(cycle.Nodes))
+		for _, node := range cycle.Nodes {
+			nodes[node] = true
+			inComponent[node] = true
+		}
+		// Tutti gli archi interni a una componente fortemente connessa appartengono a un ciclo
+		for _, node := range cycle.Nodes {
+			for _, dep := range g[node] {
+				if inComponent[dep] {
+					edges[Edge{From: node, To: dep}] = true
+				}
+			}
+		}
+	}
+
+	return nodes, edges
+}
+
+// sortedEdges restituisce tutti gli archi del grafo verso nodi presenti, in ordine deterministico
+func (g DependencyGraph) sortedEdges() []Edge {
+	edges := make([]Edge, 0)
+	for _, node := range g.Nodes() {
+		deps := append([]string(nil), g[node]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, exists := g[dep]; exists {
+				edges = append(edges, Edge{From: node, To: dep})
+			}
+		}
+	}
+	return edges
+}
+
+// DOT esporta il grafo nel formato Graphviz DOT.
+// Gli archi vanno dal progetto alla sua dipendenza.
+func (g DependencyGraph) DOT(opts ExportOptions) string {
+	cycleNodes, cycleEdges := g.cycleMembers()
+
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, node := range g.Nodes() {
+		if opts.HighlightCycles && cycleNodes[node] {
+			fmt.Fprintf(&b, "  %q [color=red, fontcolor=red];\n", node)
+		} else {
+			fmt.Fprintf(&b, "  %q;\n", node)
+		}
+	}
+
+	for _, edge := range g.sortedEdges() {
+		attrs := make([]string, 0, 2)
+		if labels := opts.labels(edge); len(labels) > 0 {
+			attrs = append(attrs, fmt.Sprintf("label=%q", strings.Join(labels, ", ")))
+		}
+		if opts.HighlightCycles && cycleEdges[edge] {
+			attrs = append(attrs, "color=red")
+		}
+
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, "  %q -> %q [%s];\n", edge.From, edge.To, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(&b, "  %q -> %q;\n", edge.From, edge.To)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid esporta il grafo come flowchart Mermaid.
+// I nodi usano identificatori sintetici (n0, n1, ...) perché i nomi dei progetti
+// possono contenere caratteri non ammessi dalla sintassi Mermaid.
+func (g DependencyGraph) Mermaid(opts ExportOptions) string {
+	cycleNodes, cycleEdges := g.cycleMembers()
+
+	nodes := g.Nodes()
+	ids := make(map[string]string, len(nodes))
+	for i, node := range nodes {
+		ids[node] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, node := range nodes {
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node], mermaidEscape(node))
+	}
+
+	highlightedLinks := make([]string, 0)
+	for i, edge := range g.sortedEdges() {
+		if labels := opts.labels(edge); len(labels) > 0 {
+			fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", ids[edge.From], mermaidEscape(strings.Join(labels, ", ")), ids[edge.To])
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+		if opts.HighlightCycles && cycleEdges[edge] {
+			highlightedLinks = append(highlightedLinks, fmt.Sprintf("%d", i))
+		}
+	}
+
+	if opts.HighlightCycles && len(cycleNodes) > 0 {
+		b.WriteString("  classDef cycle stroke:#d00,stroke-width:2px,color:#d00\n")
+		for _, node := range nodes {
+			if cycleNodes[node] {
+				fmt.Fprintf(&b, "  class %s cycle\n", ids[node])
+			}
+		}
+		if len(highlightedLinks) > 0 {
+			fmt.Fprintf(&b, "  linkStyle %s stroke:#d00,stroke-width:2px\n", strings.Join(highlightedLinks, ","))
+		}
+	}
+
+	return b.String()
+}
+
+// mermaidEscape sostituisce i caratteri che interrompono le etichette Mermaid
+func mermaidEscape(text string) string {
+	return strings.ReplaceAll(text, "\"", "#quot;")
+}
+
+// jsonGraph è la rappresentazione JSON del grafo
+type jsonGraph struct {
+	Adjacency map[string][]string `json:"adjacency"`
+	Edges     []jsonEdge          `json:"edges"`
+	Cycles    [][]string          `json:"cycles,omitempty"`
+}
+
+type jsonEdge struct {
+	From   string   `json:"from"`
+	To     string   `json:"to"`
+	Labels []string `json:"labels,omitempty"`
+	Cycle  bool     `json:"cycle,omitempty"`
+}
+
+// JSON esporta il grafo come lista di adiacenza, con l'elenco degli archi etichettati
+// e, se richiesto, i cicli rilevati
+func (g DependencyGraph) JSON(opts ExportOptions) ([]byte, error) {
+	_, cycleEdges := g.cycleMembers()
+
+	out := jsonGraph{
+		Adjacency: make(map[string][]string, len(g)),
+		Edges:     make([]jsonEdge, 0),
+	}
+	for _, node := range g.Nodes() {
+		out.Adjacency[node] = make([]string, 0)
+	}
+
+	for _, edge := range g.sortedEdges() {
+		out.Adjacency[edge.From] = append(out.Adjacency[edge.From], edge.To)
+		out.Edges = append(out.Edges, jsonEdge{
+			From:   edge.From,
+			To:     edge.To,
+			Labels: opts.labels(edge),
+			Cycle:  opts.HighlightCycles && cycleEdges[edge],
+		})
+	}
+
+	if opts.HighlightCycles {
+		for _, cycle := range g.Cycles() {
+			out.Cycles = append(out.Cycles, cycle.Path)
+		}
+	}
+
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// Tree esporta il grafo come albero ASCII delle dipendenze.
+// Le radici sono i nodi da cui non dipende nessun altro; i sottoalberi già mostrati
+// vengono abbreviati e gli archi che chiudono un ciclo sono marcati.
+func (g DependencyGraph) Tree(opts ExportOptions) string {
+	_, reverseGraph := g.inDegreeAndReverse()
+	cycleNodes, _ := g.cycleMembers()
+
+	roots := make([]string, 0)
+	for _, node := range g.Nodes() {
+		if len(reverseGraph[node]) == 0 {
+			roots = append(roots, node)
+		}
+	}
+	// Nodi raggiungibili solo tramite cicli: usa come radice il primo nodo di ogni ciclo
+	for _, cycle := range g.Cycles() {
+		reachable := g.Upstream(roots...)
+		if !slices.Contains(reachable, cycle.Nodes[0]) {
+			roots = append(roots, cycle.Nodes[0])
+		}
+	}
+
+	var b strings.Builder
+	expanded := make(map[string]bool)
+
+	var walk func(node, prefix string, path map[string]bool)
+	walk = func(node, prefix string, path map[string]bool) {
+		deps := make([]string, 0)
+		for _, dep := range g[node] {
+			if _, exists := g[dep]; exists {
+				deps = append(deps, dep)
+			}
+		}
+		sort.Strings(deps)
+
+		for i, dep := range deps {
+			connector, childPrefix := "├── ", prefix+"│   "
+			if i == len(deps)-1 {
+				connector, childPrefix = "└── ", prefix+"    "
+			}
+
+			line := dep
+			if labels := opts.labels(Edge{From: node, To: dep}); len(labels) > 0 {
+				line += " [" + strings.Join(labels, ", ") + "]"
+			}
+
+			switch {
+			case path[dep]:
+				line += " ⟲ (ciclo)"
+				b.WriteString(prefix + connector + line + "\n")
+			case expanded[dep] && len(g[dep]) > 0:
+				line += " (vedi sopra)"
+				b.WriteString(prefix + connector + line + "\n")
+			default:
+				if opts.HighlightCycles && cycleNodes[dep] {
+					line += " ⟲"
+				}
+				b.WriteString(prefix + connector + line + "\n")
+				expanded[dep] = true
+				path[dep] = true
+				walk(dep, childPrefix, path)
+				delete(path, dep)
+			}
+		}
+	}
+
+	for _, root := range roots {
+		line := root
+		if opts.HighlightCycles && cycleNodes[root] {
+			line += " ⟲"
+		}
+		b.WriteString(line + "\n")
+		expanded[root] = true
+		walk(root, "", map[string]bool{root: true})
+	}
+
+	return b.String()
+}
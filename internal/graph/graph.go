@@ -1,7 +1,10 @@
 // Package graph fornisce strutture e algoritmi per la gestione di grafi di dipendenze
 package graph
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // DependencyGraph rappresenta un grafo orientato di dipendenze tra progetti
 // La chiave è il nome del progetto, il valore è la lista di progetti da cui dipende
@@ -26,25 +29,20 @@ func (g DependencyGraph) AddDependency(name string, dependency string) {
 }
 
 // TopologicalSort ordina i nodi del grafo in base alle loro dipendenze
-// Restituisce l'ordine di esecuzione corretto o un errore se ci sono cicli
-// Usa l'algoritmo di Kahn ottimizzato con reverse graph (O(n+m) invece di O(n²))
+// Restituisce l'ordine di esecuzione corretto o un *CycleError se ci sono cicli
+// A parità di dipendenze i nodi sono ordinati alfabeticamente, quindi l'ordine è deterministico
 func (g DependencyGraph) TopologicalSort() ([]string, error) {
-	// Inizializza in-degree e reverse graph
-	inDegree := make(map[string]int)
-	reverseGraph := make(map[string][]string) // chi dipende da me
-
-	// Inizializza tutti i nodi con in-degree 0
-	for node := range g {
-		inDegree[node] = 0
-	}
+	return g.TopologicalSortWithPriority(nil)
+}
 
-	// Calcola in-degree e costruisci reverse graph in una sola passata
-	for node, deps := range g {
-		inDegree[node] += len(deps)
-		for _, dep := range deps {
-			reverseGraph[dep] = append(reverseGraph[dep], node)
-		}
-	}
+// TopologicalSortWithPriority ordina i nodi del grafo in base alle loro dipendenze.
+// Tra i nodi pronti (senza dipendenze in sospeso) sceglie prima quelli presenti in priority,
+// nell'ordine indicato, e poi gli altri in ordine alfabetico.
+// Usa l'algoritmo di Kahn ottimizzato con reverse graph (O(n+m) invece di O(n²))
+func (g DependencyGraph) TopologicalSortWithPriority(priority []string) ([]string, error) {
+	// Calcola in-degree e reverse graph (chi dipende da me) in una sola passata
+	inDegree, reverseGraph := g.inDegreeAndReverse()
+	order := NewOrdering(priority)
 
 	// Coda dei nodi senza dipendenze (in-degree = 0)
 	queue := make([]string, 0)
@@ -53,6 +51,7 @@ func (g DependencyGraph) TopologicalSort() ([]string, error) {
 			queue = append(queue, node)
 		}
 	}
+	order.Sort(queue)
 
 	// Risultato dell'ordinamento topologico
 	result := make([]string, 0, len(g))
@@ -65,22 +64,65 @@ func (g DependencyGraph) TopologicalSort() ([]string, error) {
 		result = append(result, current)
 
 		// O(1) lookup: trova tutti i nodi che dipendono dal nodo corrente
+		added := false
 		for _, dependent := range reverseGraph[current] {
 			inDegree[dependent]--
 			if inDegree[dependent] == 0 {
 				queue = append(queue, dependent)
+				added = true
 			}
 		}
+
+		// Mantiene la coda ordinata così la scelta tra nodi pronti è stabile
+		if added {
+			order.Sort(queue)
+		}
 	}
 
 	// Se non tutti i nodi sono stati processati, c'è un ciclo
 	if len(result) != len(g) {
-		return nil, fmt.Errorf("dipendenze circolari rilevate nel grafo")
+		if cycles := g.Cycles(); len(cycles) > 0 {
+			return nil, &CycleError{Cycles: cycles}
+		}
+		// Nessun ciclo: alcuni nodi dipendono da nodi assenti dal grafo
+		return nil, fmt.Errorf("dipendenze non risolte nel grafo: %v", g.unresolvedNodes(result))
 	}
 
 	return result, nil
 }
 
+// inDegreeAndReverse calcola il numero di dipendenze di ogni nodo e il grafo inverso (chi dipende da me)
+func (g DependencyGraph) inDegreeAndReverse() (map[string]int, map[string][]string) {
+	inDegree := make(map[string]int, len(g))
+	reverseGraph := make(map[string][]string)
+
+	for node, deps := range g {
+		inDegree[node] += len(deps)
+		for _, dep := range deps {
+			reverseGraph[dep] = append(reverseGraph[dep], node)
+		}
+	}
+
+	return inDegree, reverseGraph
+}
+
+// unresolvedNodes restituisce i nodi esclusi dall'ordinamento, in ordine alfabetico
+func (g DependencyGraph) unresolvedNodes(sorted []string) []string {
+	done := make(map[string]bool, len(sorted))
+	for _, node := range sorted {
+		done[node] = true
+	}
+
+	unresolved := make([]string, 0)
+	for node := range g {
+		if !done[node] {
+			unresolved = append(unresolved, node)
+		}
+	}
+	sort.Strings(unresolved)
+	return unresolved
+}
+
 // HasCycles verifica se il grafo contiene cicli
 func (g DependencyGraph) HasCycles() bool {
 	_, err := g.TopologicalSort()
@@ -95,11 +137,12 @@ func (g DependencyGraph) GetDependencies(name string) []string {
 	return []string{}
 }
 
-// Nodes restituisce la lista di tutti i nodi nel grafo
+// Nodes restituisce la lista di tutti i nodi nel grafo in ordine alfabetico
 func (g DependencyGraph) Nodes() []string {
 	nodes := make([]string, 0, len(g))
 	for node := range g {
 		nodes = append(nodes, node)
 	}
+	sort.Strings(nodes)
 	return nodes
 }
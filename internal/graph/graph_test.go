@@ -1,7 +1,13 @@
 package graph
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestTopologicalSort(t *testing.T) {
@@ -14,11 +20,12 @@ func TestTopologicalSort(t *testing.T) {
 		{
 			name: "Grafo semplice senza dipendenze",
 			graph: DependencyGraph{
+				"projectC": {},
 				"projectA": {},
 				"projectB": {},
-				"projectC": {},
 			},
-			expectError: false,
+			expectedOrder: []string{"projectA", "projectB", "projectC"},
+			expectError:   false,
 		},
 		{
 			name: "Grafo con dipendenze lineari",
@@ -38,7 +45,8 @@ func TestTopologicalSort(t *testing.T) {
 				"projectC": {"projectA"},
 				"projectD": {"projectB", "projectC"},
 			},
-			expectError: false,
+			expectedOrder: []string{"projectA", "projectB", "projectC", "projectD"},
+			expectError:   false,
 		},
 		{
 			name: "Grafo con dipendenza circolare",
@@ -99,6 +107,39 @@ func TestTopologicalSort(t *testing.T) {
 	}
 }
 
+func TestTopologicalSortWithPriority(t *testing.T) {
+	g := DependencyGraph{
+		"alpha":   {},
+		"beta":    {},
+		"gamma":   {},
+		"delta":   {"alpha"},
+		"epsilon": {"delta"},
+	}
+
+	// Senza priorità: ordine alfabetico tra i nodi pronti, stabile tra più esecuzioni
+	expected := []string{"alpha", "beta", "delta", "epsilon", "gamma"}
+	for i := 0; i < 10; i++ {
+		result, err := g.TopologicalSort()
+		if err != nil {
+			t.Fatalf("Errore non previsto: %v", err)
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("Ordine errato: atteso %v, ottenuto %v", expected, result)
+		}
+	}
+
+	// Con priorità: i nodi indicati vengono scelti per primi quando sono pronti,
+	// senza mai violare le dipendenze
+	result, err := g.TopologicalSortWithPriority([]string{"epsilon", "gamma", "delta"})
+	if err != nil {
+		t.Fatalf("Errore non previsto: %v", err)
+	}
+	expected = []string{"gamma", "alpha", "delta", "epsilon", "beta"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Ordine errato: atteso %v, ottenuto %v", expected, result)
+	}
+}
+
 func TestDependencyGraphOperations(t *testing.T) {
 	t.Run("NewDependencyGraph", func(t *testing.T) {
 		g := NewDependencyGraph()
@@ -168,3 +209,299 @@ func TestDependencyGraphOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestLevels(t *testing.T) {
+	g := DependencyGraph{
+		"projectA": {},
+		"projectB": {},
+		"projectC": {"projectA", "projectB"},
+		"projectD": {"projectA"},
+		"projectE": {"projectC", "projectD"},
+	}
+
+	levels, err := g.Levels()
+	if err != nil {
+		t.Fatalf("Errore non previsto: %v", err)
+	}
+
+	expected := [][]string{
+		{"projectA", "projectB"},
+		{"projectC", "projectD"},
+		{"projectE"},
+	}
+	if !reflect.DeepEqual(levels, expected) {
+		t.Errorf("Livelli errati: atteso %v, ottenuto %v", expected, levels)
+	}
+
+	cyclic := DependencyGraph{
+		"A": {"B"},
+		"B": {"A"},
+	}
+	if _, err := cyclic.Levels(); err == nil {
+		t.Error("Ci si aspettava un errore per un grafo con cicli")
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	t.Run("Rispetta le dipendenze", func(t *testing.T) {
+		g := DependencyGraph{
+			"projectA": {},
+			"projectB": {},
+			"projectC": {"projectA", "projectB"},
+			"projectD": {"projectC"},
+		}
+
+		var mu sync.Mutex
+		finished := make(map[string]bool)
+		statuses, err := g.Schedule(3, nil, func(node string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, dep := range g[node] {
+				if !finished[dep] {
+					t.Errorf("%s avviato prima della dipendenza %s", node, dep)
+				}
+			}
+			finished[node] = true
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Errore non previsto: %v", err)
+		}
+
+		for node := range g {
+			if statuses[node] != StatusSucceeded {
+				t.Errorf("Stato errato per %s: atteso %v, ottenuto %v", node, StatusSucceeded, statuses[node])
+			}
+		}
+	})
+
+	t.Run("Salta i dipendenti di un nodo fallito", func(t *testing.T) {
+		g := DependencyGraph{
+			"projectA": {},
+			"projectB": {},
+			"projectC": {"projectA"},
+			"projectD": {"projectC", "projectB"},
+			"projectE": {"projectB"},
+		}
+
+		statuses, err := g.Schedule(2, nil, func(node string) error {
+			if node == "projectA" {
+				return errors.New("build fallita")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Errore non previsto: %v", err)
+		}
+
+		expected := map[string]NodeStatus{
+			"projectA": StatusFailed,
+			"projectB": StatusSucceeded,
+			"projectC": StatusSkipped,
+			"projectD": StatusSkipped,
+			"projectE": StatusSucceeded,
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Errorf("Stati errati: atteso %v, ottenuto %v", expected, statuses)
+		}
+	})
+
+	t.Run("Interrompe lo scheduling con ErrStop", func(t *testing.T) {
+		g := DependencyGraph{
+			"projectA": {},
+			"projectB": {"projectA"},
+			"projectC": {},
+			"projectD": {"projectC"},
+		}
+
+		statuses, err := g.Schedule(1, []string{"projectA"}, func(node string) error {
+			if node == "projectA" {
+				return fmt.Errorf("build fallita: %w", ErrStop)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Errore non previsto: %v", err)
+		}
+
+		expected := map[string]NodeStatus{
+			"projectA": StatusFailed,
+			"projectB": StatusSkipped,
+			"projectC": StatusCancelled,
+			"projectD": StatusCancelled,
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Errorf("Stati errati: atteso %v, ottenuto %v", expected, statuses)
+		}
+	})
+
+	t.Run("Grafo con cicli", func(t *testing.T) {
+		g := DependencyGraph{
+			"A": {"B"},
+			"B": {"A"},
+		}
+		if _, err := g.Schedule(2, nil, func(string) error { return nil }); err == nil {
+			t.Error("Ci si aspettava un errore per un grafo con cicli")
+		}
+	})
+}
+
+func TestCycles(t *testing.T) {
+	g := DependencyGraph{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+		"d": {"e"},
+		"e": {"d", "a"},
+		"f": {"a"},
+		"g": {"g"},
+	}
+
+	_, err := g.TopologicalSort()
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Ci si aspettava un *CycleError, ottenuto %v", err)
+	}
+
+	expected := []string{"a -> b -> c -> a", "d -> e -> d", "g -> g"}
+	if len(cycleErr.Cycles) != len(expected) {
+		t.Fatalf("Numero di cicli errato: atteso %d, ottenuto %d (%v)", len(expected), len(cycleErr.Cycles), cycleErr)
+	}
+	for i, cycle := range cycleErr.Cycles {
+		if cycle.String() != expected[i] {
+			t.Errorf("Ciclo %d errato: atteso %q, ottenuto %q", i, expected[i], cycle.String())
+		}
+	}
+
+	expectedMsg := "dipendenze circolari rilevate nel grafo: a -> b -> c -> a; d -> e -> d; g -> g"
+	if cycleErr.Error() != expectedMsg {
+		t.Errorf("Messaggio errato: atteso %q, ottenuto %q", expectedMsg, cycleErr.Error())
+	}
+}
+
+func TestClosures(t *testing.T) {
+	g := DependencyGraph{
+		"core":    {},
+		"util":    {"core"},
+		"api":     {"util"},
+		"service": {"api", "core"},
+		"tool":    {},
+	}
+
+	if got := g.Upstream("api"); !reflect.DeepEqual(got, []string{"api", "core", "util"}) {
+		t.Errorf("Upstream errato: ottenuto %v", got)
+	}
+
+	if got := g.Downstream("util"); !reflect.DeepEqual(got, []string{"api", "service", "util"}) {
+		t.Errorf("Downstream errato: ottenuto %v", got)
+	}
+
+	sub := g.Subgraph([]string{"api", "service"})
+	expected := DependencyGraph{
+		"api":     {},
+		"service": {"api"},
+	}
+	if !reflect.DeepEqual(sub, expected) {
+		t.Errorf("Subgraph errato: atteso %v, ottenuto %v", expected, sub)
+	}
+
+	restricted, err := g.Restrict([]string{"util"}, []string{"api"})
+	if err != nil {
+		t.Fatalf("Restrict ha restituito un errore: %v", err)
+	}
+	if got := restricted.Nodes(); !reflect.DeepEqual(got, []string{"api", "core", "service", "util"}) {
+		t.Errorf("Restrict errato: ottenuto %v", got)
+	}
+
+	if _, err := g.Restrict(nil, []string{"missing"}); err == nil {
+		t.Error("Restrict dovrebbe fallire per un progetto non presente nel grafo")
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	g := DependencyGraph{
+		"core":    {},
+		"util":    {"core"},
+		"api":     {"core"},
+		"service": {"api", "util"},
+	}
+	durations := map[string]time.Duration{
+		"core":    10 * time.Second,
+		"util":    30 * time.Second,
+		"api":     5 * time.Second,
+		"service": 20 * time.Second,
+	}
+
+	path, total, err := g.CriticalPath(durations)
+	if err != nil {
+		t.Fatalf("Errore non previsto: %v", err)
+	}
+	if !reflect.DeepEqual(path, []string{"core", "util", "service"}) || total != 60*time.Second {
+		t.Errorf("Percorso critico errato: ottenuto %v (%v)", path, total)
+	}
+
+	// Sequenziale: somma delle durate; con due job api e util procedono in parallelo
+	if got, _ := g.EstimateSchedule(1, nil, durations); got != 65*time.Second {
+		t.Errorf("Stima sequenziale errata: ottenuto %v", got)
+	}
+	if got, _ := g.EstimateSchedule(2, nil, durations); got != 60*time.Second {
+		t.Errorf("Stima con 2 job errata: ottenuto %v", got)
+	}
+
+	if _, _, err := (DependencyGraph{"a": {"b"}, "b": {"a"}}).CriticalPath(durations); err == nil {
+		t.Error("Ci si aspettava un errore per un grafo con cicli")
+	}
+}
+
+func TestExport(t *testing.T) {
+	g := DependencyGraph{
+		"a": {"b"},
+		"b": {"a"},
+		"c": {"a"},
+	}
+	opts := ExportOptions{
+		EdgeLabels: func(edge Edge) []string {
+			if edge.To == "a" {
+				return []string{"parent"}
+			}
+			return nil
+		},
+		HighlightCycles: true,
+	}
+
+	expectedDOT := `digraph dependencies {
+  rankdir=LR;
+  node [shape=box];
+  "a" [color=red, fontcolor=red];
+  "b" [color=red, fontcolor=red];
+  "c";
+  "a" -> "b" [color=red];
+  "b" -> "a" [label="parent", color=red];
+  "c" -> "a" [label="parent"];
+}
+`
+	if got := g.DOT(opts); got != expectedDOT {
+		t.Errorf("DOT errato:\n%s", got)
+	}
+
+	expectedTree := `c
+└── a [parent] ⟲
+    └── b ⟲
+        └── a [parent] ⟲ (ciclo)
+`
+	if got := g.Tree(opts); got != expectedTree {
+		t.Errorf("Albero errato:\n%s", got)
+	}
+
+	data, err := g.JSON(opts)
+	if err != nil {
+		t.Fatalf("Errore non previsto: %v", err)
+	}
+	if !strings.Contains(string(data), `"cycles": [`) || !strings.Contains(string(data), `"labels": [`) {
+		t.Errorf("JSON senza cicli o etichette:\n%s", data)
+	}
+
+	if got := g.Mermaid(opts); !strings.Contains(got, `n1 -->|"parent"| n0`) || !strings.Contains(got, "linkStyle 0,1") {
+		t.Errorf("Mermaid errato:\n%s", got)
+	}
+}
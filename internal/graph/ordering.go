@@ -0,0 +1,43 @@
+package graph
+
+import "sort"
+
+// Ordering definisce l'ordine di preferenza tra nodi indipendenti del grafo.
+// I nodi presenti nella lista di priorità vengono prima, nell'ordine indicato;
+// tutti gli altri seguono in ordine alfabetico.
+type Ordering struct {
+	rank map[string]int
+}
+
+// NewOrdering crea un ordinamento a partire da una lista di priorità (può essere vuota)
+func NewOrdering(priority []string) Ordering {
+	rank := make(map[string]int, len(priority))
+	for i, node := range priority {
+		if _, exists := rank[node]; !exists {
+			rank[node] = i
+		}
+	}
+	return Ordering{rank: rank}
+}
+
+// Less indica se il nodo a precede il nodo b
+func (o Ordering) Less(a, b string) bool {
+	rankA, prioA := o.rank[a]
+	rankB, prioB := o.rank[b]
+
+	switch {
+	case prioA && prioB:
+		return rankA < rankB
+	case prioA != prioB:
+		return prioA
+	default:
+		return a < b
+	}
+}
+
+// Sort ordina i nodi sul posto secondo l'ordinamento
+func (o Ordering) Sort(nodes []string) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return o.Less(nodes[i], nodes[j])
+	})
+}
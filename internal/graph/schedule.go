@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// NodeStatus rappresenta l'esito dell'esecuzione di un nodo durante lo scheduling
+type NodeStatus int
+
+const (
+	// StatusPending indica un nodo non ancora eseguito
+	StatusPending NodeStatus = iota
+	// StatusSucceeded indica un nodo eseguito con successo
+	StatusSucceeded
+	// StatusFailed indica un nodo la cui esecuzione è fallita
+	StatusFailed
+	// StatusSkipped indica un nodo non eseguito perché una sua dipendenza è fallita
+	StatusSkipped
+	// StatusCancelled indica un nodo non eseguito perché lo scheduling è stato interrotto (vedi ErrStop)
+	StatusCancelled
+)
+
+// ErrStop, restituito (anche incapsulato) da un NodeRunner, segnala un nodo fallito dopo il quale
+// non va avviato nessun altro nodo: le esecuzioni in corso vengono attese e i nodi non ancora avviati
+// terminano nello stato StatusCancelled
+var ErrStop = errors.New("scheduling interrotto")
+
+// NodeRunner è la funzione eseguita dallo scheduler per ogni nodo del grafo
+type NodeRunner func(node string) error
+
+// Levels raggruppa i nodi del grafo in insiemi pronti (ready set):
+// ogni livello contiene i nodi le cui dipendenze si trovano tutte nei livelli precedenti,
+// quindi i nodi di uno stesso livello possono essere eseguiti in parallelo.
+// Restituisce un errore se il grafo contiene cicli.
+func (g DependencyGraph) Levels() ([][]string, error) {
+	if _, err := g.TopologicalSort(); err != nil {
+		return nil, err
+	}
+
+	inDegree, reverseGraph := g.inDegreeAndReverse()
+
+	current := make([]string, 0)
+	for node, degree := range inDegree {
+		if degree == 0 {
+			current = append(current, node)
+		}
+	}
+
+	levels := make([][]string, 0)
+	for len(current) > 0 {
+		sort.Strings(current)
+		levels = append(levels, current)
+
+		next := make([]string, 0)
+		for _, node := range current {
+			for _, dependent := range reverseGraph[node] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		current = next
+	}
+
+	return levels, nil
+}
+
+// Schedule esegue run su tutti i nodi del grafo con al massimo jobs esecuzioni concorrenti.
+// Un nodo viene avviato non appena tutte le sue dipendenze sono terminate con successo;
+// i nodi che dipendono (anche indirettamente) da un nodo fallito vengono saltati.
+// Se un nodo fallisce con ErrStop non vengono avviati altri nodi.
+// Tra i nodi pronti vengono avviati prima quelli in priority, poi gli altri in ordine alfabetico.
+// Restituisce l'esito di ogni nodo o un errore se il grafo contiene cicli.
+func (g DependencyGraph) Schedule(jobs int, priority []string, run NodeRunner) (map[string]NodeStatus, error) {
+	if _, err := g.TopologicalSort(); err != nil {
+		return nil, err
+	}
+
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	inDegree, reverseGraph := g.inDegreeAndReverse()
+	order := NewOrdering(priority)
+
+	status := make(map[string]NodeStatus, len(g))
+	ready := make([]string, 0)
+	for node, degree := range inDegree {
+		status[node] = StatusPending
+		if degree == 0 {
+			ready = append(ready, node)
+		}
+	}
+	order.Sort(ready)
+
+	type result struct {
+		node string
+		err  error
+	}
+	results := make(chan result)
+
+	running := 0
+	completed := 0
+	stopped := false
+	for completed < len(g) {
+		// Avvia tutti i nodi pronti fino al limite di concorrenza
+		for !stopped && running < jobs && len(ready) > 0 {
+			node := ready[0]
+			ready = ready[1:]
+			running++
+			go func(n string) {
+				results <- result{node: n, err: run(n)}
+			}(node)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		completed++
+
+		if res.err != nil {
+			status[res.node] = StatusFailed
+			completed += skipDependents(res.node, reverseGraph, status)
+			stopped = stopped || errors.Is(res.err, ErrStop)
+			continue
+		}
+
+		status[res.node] = StatusSucceeded
+		for _, dependent := range reverseGraph[res.node] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 && status[dependent] == StatusPending {
+				ready = append(ready, dependent)
+			}
+		}
+		order.Sort(ready)
+	}
+
+	// Dopo un'interruzione i nodi mai avviati vengono annullati
+	for node, nodeStatus := range status {
+		if nodeStatus == StatusPending {
+			status[node] = StatusCancelled
+		}
+	}
+
+	return status, nil
+}
+
+// skipDependents marca come saltati tutti i nodi che dipendono transitivamente da node.
+// Restituisce il numero di nodi marcati.
+func skipDependents(node string, reverseGraph map[string][]string, status map[string]NodeStatus) int {
+	skipped := 0
+	for _, dependent := range reverseGraph[node] {
+		if status[dependent] != StatusPending {
+			continue
+		}
+		status[dependent] = StatusSkipped
+		skipped++
+		skipped += skipDependents(dependent, reverseGraph, status)
+	}
+	return skipped
+}
@@ -0,0 +1,271 @@
+// Package history conserva le durate delle build di ogni progetto e delle sue fasi,
+// per mostrare statistiche e stimare la durata delle esecuzioni successive
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StoreFileName è il nome del file che contiene lo storico delle build
+const StoreFileName = "history.json"
+
+// filePermissions sono i permessi del file dello storico
+const filePermissions = 0644
+
+// maxRecords è il numero massimo di build conservate: le più vecchie vengono scartate
+const maxRecords = 2000
+
+// estimateWindow è il numero di build riuscite più recenti usate per la stima della durata
+const estimateWindow = 5
+
+// PhaseRecord è la durata di una fase della build in un modulo
+type PhaseRecord struct {
+	Name     string        `json:"name"`
+	Module   string        `json:"module,omitempty"`
+	Duration time.Duration `json:"duration"` // Nanosecondi
+}
+
+// Record descrive la build di un progetto in un'esecuzione
+type Record struct {
+	RunID     string        `json:"run_id"`
+	Project   string        `json:"project"`
+	System    string        `json:"system,omitempty"` // Build system che ha eseguito la build (es. maven, gradle, npm)
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"` // Nanosecondi
+	Succeeded bool          `json:"succeeded"`
+	Phases    []PhaseRecord `json:"phases,omitempty"`
+}
+
+// Store è lo storico delle build, salvato come file JSON nella directory del profilo
+type Store struct {
+	Records []Record `json:"records"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// Load carica lo storico salvato nella directory indicata.
+// Se il file non esiste restituisce uno storico vuoto.
+func Load(dir string) (*Store, error) {
+	store := &Store{path: filepath.Join(dir, StoreFileName)}
+
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("impossibile leggere lo storico delle build: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("file dello storico delle build non valido: %w", err)
+	}
+	return store, nil
+}
+
+// Add aggiunge la build di un progetto e salva subito lo storico.
+// È sicuro per l'uso concorrente.
+func (s *Store) Add(record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Records = append(s.Records, record)
+	if len(s.Records) > maxRecords {
+		s.Records = slices.Clone(s.Records[len(s.Records)-maxRecords:])
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("impossibile serializzare lo storico delle build: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, filePermissions); err != nil {
+		return fmt.Errorf("impossibile salvare lo storico delle build: %w", err)
+	}
+	return nil
+}
+
+// Estimates restituisce la durata prevista di ogni progetto: la media delle ultime build riuscite.
+// I progetti senza build riuscite nello storico non compaiono nella mappa.
+func (s *Store) Estimates() map[string]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	durations := make(map[string][]time.Duration)
+	for _, record := range s.Records {
+		if record.Succeeded {
+			durations[record.Project] = append(durations[record.Project], record.Duration)
+		}
+	}
+
+	estimates := make(map[string]time.Duration, len(durations))
+	for project, values := range durations {
+		if len(values) > estimateWindow {
+			values = values[len(values)-estimateWindow:]
+		}
+		estimates[project] = average(values)
+	}
+	return estimates
+}
+
+// ProjectStats riassume le build di un progetto
+type ProjectStats struct {
+	Project   string
+	Builds    int           // Build registrate
+	Failures  int           // Build fallite
+	Average   time.Duration // Media delle build riuscite
+	Last      time.Duration // Ultima build riuscita
+	Min       time.Duration
+	Max       time.Duration
+	Trend     float64 // Variazione percentuale delle build recenti rispetto alle precedenti (0 se non calcolabile)
+	HasTrend  bool
+	LastBuild time.Time
+}
+
+// ModuleStats è la durata media di un modulo per build
+type ModuleStats struct {
+	Project string
+	Module  string
+	Average time.Duration
+	Builds  int
+}
+
+// PhaseStats è la durata media di una fase della build per build
+type PhaseStats struct {
+	Name    string
+	Average time.Duration
+	Builds  int
+}
+
+// Stats contiene le statistiche calcolate dallo storico
+type Stats struct {
+	Projects []ProjectStats // Ordinati per durata media decrescente
+	Modules  []ModuleStats  // Ordinati per durata media decrescente
+	Phases   []PhaseStats   // Ordinate per durata media decrescente
+}
+
+// Stats calcola le statistiche sulle ultime limit build di ogni progetto (limit <= 0 = tutte):
+// build fallite e durate delle build riuscite vengono ricavate dalla stessa finestra.
+// Il trend confronta la media della metà più recente delle build con quella della metà precedente.
+func (s *Store) Stats(limit int) Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byProject := make(map[string][]Record)
+	for _, record := range s.Records {
+		byProject[record.Project] = append(byProject[record.Project], record)
+	}
+
+	var stats Stats
+	modules := make(map[[2]string][]time.Duration)
+	phases := make(map[string][]time.Duration)
+
+	for project, window := range byProject {
+		// La finestra comprende le ultime limit build, riuscite o fallite, in ordine cronologico
+		slices.SortStableFunc(window, func(a, b Record) int { return a.StartedAt.Compare(b.StartedAt) })
+		if limit > 0 && len(window) > limit {
+			window = window[len(window)-limit:]
+		}
+
+		projectStats := ProjectStats{
+			Project:   project,
+			Builds:    len(window),
+			LastBuild: window[len(window)-1].StartedAt,
+		}
+
+		records := make([]Record, 0, len(window))
+		for _, record := range window {
+			if record.Succeeded {
+				records = append(records, record)
+			} else {
+				projectStats.Failures++
+			}
+		}
+
+		durations := make([]time.Duration, 0, len(records))
+		for _, record := range records {
+			durations = append(durations, record.Duration)
+
+			// Somma per build le durate di moduli e fasi, che possono ripetersi nella stessa build
+			perModule := make(map[string]time.Duration)
+			perPhase := make(map[string]time.Duration)
+			for _, phase := range record.Phases {
+				perModule[phase.Module] += phase.Duration
+				perPhase[phase.Name] += phase.Duration
+			}
+			for module, duration := range perModule {
+				key := [2]string{project, module}
+				modules[key] = append(modules[key], duration)
+			}
+			for name, duration := range perPhase {
+				phases[name] = append(phases[name], duration)
+			}
+		}
+
+		if len(durations) > 0 {
+			projectStats.Average = average(durations)
+			projectStats.Last = durations[len(durations)-1]
+			projectStats.Min = slices.Min(durations)
+			projectStats.Max = slices.Max(durations)
+		}
+		if len(durations) >= 2 {
+			older := average(durations[:len(durations)/2])
+			recent := average(durations[len(durations)/2:])
+			if older > 0 {
+				projectStats.Trend = (float64(recent) - float64(older)) / float64(older) * 100
+				projectStats.HasTrend = true
+			}
+		}
+		stats.Projects = append(stats.Projects, projectStats)
+	}
+
+	for key, durations := range modules {
+		stats.Modules = append(stats.Modules, ModuleStats{
+			Project: key[0],
+			Module:  key[1],
+			Average: average(durations),
+			Builds:  len(durations),
+		})
+	}
+	for name, durations := range phases {
+		stats.Phases = append(stats.Phases, PhaseStats{Name: name, Average: average(durations), Builds: len(durations)})
+	}
+
+	sort.Slice(stats.Projects, func(i, j int) bool {
+		if stats.Projects[i].Average != stats.Projects[j].Average {
+			return stats.Projects[i].Average > stats.Projects[j].Average
+		}
+		return stats.Projects[i].Project < stats.Projects[j].Project
+	})
+	sort.Slice(stats.Modules, func(i, j int) bool {
+		if stats.Modules[i].Average != stats.Modules[j].Average {
+			return stats.Modules[i].Average > stats.Modules[j].Average
+		}
+		return stats.Modules[i].Project+stats.Modules[i].Module < stats.Modules[j].Project+stats.Modules[j].Module
+	})
+	sort.Slice(stats.Phases, func(i, j int) bool {
+		if stats.Phases[i].Average != stats.Phases[j].Average {
+			return stats.Phases[i].Average > stats.Phases[j].Average
+		}
+		return stats.Phases[i].Name < stats.Phases[j].Name
+	})
+	return stats
+}
+
+// average restituisce la media delle durate (0 se vuote)
+func average(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+	return total / time.Duration(len(durations))
+}
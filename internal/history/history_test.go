@@ -0,0 +1,136 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// record crea una build del progetto con la durata indicata in secondi
+func record(project string, seconds int, succeeded bool) Record {
+	return Record{Project: project, Duration: time.Duration(seconds) * time.Second, Succeeded: succeeded}
+}
+
+// TestEstimates verifica che la stima sia la media delle ultime build riuscite
+func TestEstimates(t *testing.T) {
+	store := &Store{path: filepath.Join(t.TempDir(), StoreFileName)}
+	// Le prime due build escono dalla finestra; la build fallita non conta
+	for _, seconds := range []int{100, 100, 10, 20, 30, 40, 50} {
+		if err := store.Add(record("core", seconds, true)); err != nil {
+			t.Fatalf("Add() errore inatteso: %v", err)
+		}
+	}
+	_ = store.Add(record("core", 500, false))
+	_ = store.Add(record("web", 60, false))
+
+	estimates := store.Estimates()
+	if estimate := estimates["core"]; estimate != 30*time.Second {
+		t.Errorf("stima di core = %s, attesa 30s", estimate)
+	}
+	if _, found := estimates["web"]; found {
+		t.Error("un progetto senza build riuscite non deve avere una stima")
+	}
+
+	reloaded, err := Load(t.TempDir())
+	if err != nil || len(reloaded.Records) != 0 {
+		t.Fatalf("Load() di una directory vuota = %d record, %v", len(reloaded.Records), err)
+	}
+}
+
+// TestStats verifica media, estremi, fallimenti e trend di un progetto
+func TestStats(t *testing.T) {
+	store := &Store{path: filepath.Join(t.TempDir(), StoreFileName)}
+	for _, r := range []Record{
+		record("core", 10, true),
+		record("core", 99, false),
+		record("core", 20, true),
+		record("core", 30, true),
+		record("api", 5, true),
+	} {
+		_ = store.Add(r)
+	}
+
+	stats := store.Stats(0)
+	if len(stats.Projects) != 2 || stats.Projects[0].Project != "core" {
+		t.Fatalf("Projects = %+v, atteso core per primo (durata media maggiore)", stats.Projects)
+	}
+	core := stats.Projects[0]
+	if core.Builds != 4 || core.Failures != 1 || core.Average != 20*time.Second ||
+		core.Min != 10*time.Second || core.Max != 30*time.Second || core.Last != 30*time.Second {
+		t.Errorf("statistiche di core = %+v", core)
+	}
+	// Metà precedente [10s], metà recente [20s, 30s]: +150%
+	if !core.HasTrend || core.Trend != 150 {
+		t.Errorf("trend di core = %v (%v), atteso +150%%", core.Trend, core.HasTrend)
+	}
+	if api := stats.Projects[1]; api.HasTrend {
+		t.Error("con una sola build il trend non è calcolabile")
+	}
+
+	// Con limit vengono considerate solo le ultime build
+	limited := store.Stats(2)
+	if core := limited.Projects[0]; core.Average != 25*time.Second || core.Trend != 50 {
+		t.Errorf("statistiche di core con limit 2 = media %s, trend %v; attese 25s e +50%%", core.Average, core.Trend)
+	}
+}
+
+// TestStatsLimitWithFailures verifica che build riuscite e fallite vengano contate nella stessa finestra
+func TestStatsLimitWithFailures(t *testing.T) {
+	store := &Store{path: filepath.Join(t.TempDir(), StoreFileName)}
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local)
+	for i, r := range []Record{
+		record("core", 10, true),
+		record("core", 99, false),
+		record("core", 99, false),
+		record("core", 99, false),
+		record("core", 20, true),
+		record("core", 99, false),
+		record("core", 30, true),
+	} {
+		r.StartedAt = start.Add(time.Duration(i) * time.Minute)
+		_ = store.Add(r)
+	}
+
+	// Ultime 3 build: 20s, fallita, 30s
+	core := store.Stats(3).Projects[0]
+	if core.Builds != 3 || core.Failures != 1 || core.Average != 25*time.Second || core.Min != 20*time.Second {
+		t.Errorf("statistiche di core con limit 3 = %+v, attese 3 build, 1 fallita, media 25s", core)
+	}
+	if !core.LastBuild.Equal(start.Add(6 * time.Minute)) {
+		t.Errorf("ultima build di core = %s, attesa %s", core.LastBuild, start.Add(6*time.Minute))
+	}
+
+	// I fallimenti fuori dalla finestra non vengono contati
+	core = store.Stats(2).Projects[0]
+	if core.Builds != 2 || core.Failures != 1 || core.Average != 30*time.Second {
+		t.Errorf("statistiche di core con limit 2 = %+v, attese 2 build, 1 fallita, media 30s", core)
+	}
+}
+
+// TestAddCap verifica che lo storico conservi solo le build più recenti
+func TestAddCap(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() errore inatteso: %v", err)
+	}
+	store.Records = make([]Record, maxRecords)
+	for i := range store.Records {
+		store.Records[i] = Record{RunID: fmt.Sprint(i), Project: "core"}
+	}
+	if err := store.Add(Record{RunID: "ultima", Project: "core"}); err != nil {
+		t.Fatalf("Add() errore inatteso: %v", err)
+	}
+
+	reloaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() errore inatteso: %v", err)
+	}
+	if len(reloaded.Records) != maxRecords {
+		t.Fatalf("record conservati = %d, attesi %d", len(reloaded.Records), maxRecords)
+	}
+	if first, last := reloaded.Records[0].RunID, reloaded.Records[maxRecords-1].RunID; first != "1" || last != "ultima" {
+		t.Errorf("record conservati da %s a %s, attesi da 1 a ultima", first, last)
+	}
+}
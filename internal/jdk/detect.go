@@ -0,0 +1,100 @@
+// Package jdk sceglie il JDK con cui compilare ogni progetto: da configurazione,
+// dai file di versione del progetto (.sdkmanrc, .java-version, .tool-versions) o dal pom.xml
+package jdk
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven"
+)
+
+// versionPattern riconosce la versione in identificatori come "21", "1.8", "17.0.9-tem" o "temurin-21.0.2+13"
+var versionPattern = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+
+// Requirement è la versione Java richiesta da un progetto
+type Requirement struct {
+	Version    string // Versione o identificatore dichiarato (es. "21", "17.0.9-tem")
+	Major      int    // Versione principale (es. 21; 8 per "1.8")
+	Source     string // File da cui è stata letta la versione
+	Identifier string // Identificatore dello strumento (sdkman o asdf) usato per cercare il JDK installato
+}
+
+// DetectRequirement legge la versione Java richiesta dal progetto nella directory indicata,
+// cercando nell'ordine .sdkmanrc, .java-version, .tool-versions e le proprietà del pom.xml.
+// Restituisce false se il progetto non dichiara una versione.
+func DetectRequirement(projectDir string) (Requirement, bool) {
+	if value := readKeyValue(filepath.Join(projectDir, ".sdkmanrc"), "java", "="); value != "" {
+		return newRequirement(value, ".sdkmanrc", value)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(projectDir, ".java-version")); err == nil {
+		if value := strings.TrimSpace(string(data)); value != "" {
+			return newRequirement(value, ".java-version", "")
+		}
+	}
+
+	if value := readKeyValue(filepath.Join(projectDir, ".tool-versions"), "java", " "); value != "" {
+		return newRequirement(value, ".tool-versions", value)
+	}
+
+	if value := maven.JavaRelease(projectDir); value != "" {
+		return newRequirement(value, "pom.xml", "")
+	}
+	return Requirement{}, false
+}
+
+// newRequirement costruisce il requisito se la versione è riconoscibile
+func newRequirement(version, source, identifier string) (Requirement, bool) {
+	major := MajorVersion(version)
+	if major == 0 {
+		return Requirement{}, false
+	}
+	return Requirement{Version: version, Major: major, Source: source, Identifier: identifier}, true
+}
+
+// MajorVersion restituisce la versione principale di Java contenuta in version
+// (es. "21.0.2-tem" -> 21, "1.8" -> 8). Restituisce 0 se non è riconoscibile.
+func MajorVersion(version string) int {
+	matches := versionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return 0
+	}
+	major, _ := strconv.Atoi(matches[1])
+	if major == 1 && matches[2] != "" {
+		major, _ = strconv.Atoi(matches[2])
+	}
+	return major
+}
+
+// readKeyValue restituisce il valore della chiave key in un file di righe "chiave<sep>valore",
+// ignorando commenti e righe vuote. Restituisce una stringa vuota se il file o la chiave non esistono.
+func readKeyValue(path, key, separator string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, separator)
+		if !found || strings.TrimSpace(name) != key {
+			continue
+		}
+		// .tool-versions può elencare più versioni alternative: vale la prima
+		if fields := strings.Fields(value); len(fields) > 0 {
+			return fields[0]
+		}
+		return ""
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package jdk
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Installation è un JDK installato localmente
+type Installation struct {
+	Home    string // Directory del JDK (valore di JAVA_HOME)
+	Version string // Versione letta dal file release (es. "21.0.2")
+	Major   int
+}
+
+// installationRoots restituisce le directory che contengono JDK installati,
+// secondo le convenzioni dei sistemi operativi e degli strumenti più diffusi
+func installationRoots() []string {
+	home, _ := os.UserHomeDir()
+	roots := []string{
+		sdkmanCandidatesDir(),
+		asdfInstallsDir(),
+		filepath.Join(home, ".jdks"), // JDK scaricati da IntelliJ IDEA
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		roots = append(roots, "/Library/Java/JavaVirtualMachines",
+			filepath.Join(home, "Library", "Java", "JavaVirtualMachines"))
+	case "windows":
+		for _, programFiles := range []string{os.Getenv("ProgramFiles"), os.Getenv("ProgramW6432")} {
+			if programFiles != "" {
+				roots = append(roots, filepath.Join(programFiles, "Java"), filepath.Join(programFiles, "Eclipse Adoptium"))
+			}
+		}
+	default:
+		roots = append(roots, "/usr/lib/jvm", "/usr/java", "/opt/java")
+	}
+	return roots
+}
+
+// sdkmanCandidatesDir restituisce la directory dei JDK installati con SDKMAN!
+func sdkmanCandidatesDir() string {
+	dir := os.Getenv("SDKMAN_DIR")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".sdkman")
+	}
+	return filepath.Join(dir, "candidates", "java")
+}
+
+// asdfInstallsDir restituisce la directory dei JDK installati con asdf
+func asdfInstallsDir() string {
+	dir := os.Getenv("ASDF_DATA_DIR")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".asdf")
+	}
+	return filepath.Join(dir, "installs", "java")
+}
+
+// Discover cerca i JDK installati nelle directory convenzionali.
+// I JDK sono ordinati per directory; quelli senza file release vengono ignorati.
+func Discover() []Installation {
+	seen := make(map[string]bool)
+	installations := make([]Installation, 0)
+	for _, root := range installationRoots() {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+			home := filepath.Join(root, entry.Name())
+			if runtime.GOOS == "darwin" {
+				if contents := filepath.Join(home, "Contents", "Home"); isDir(contents) {
+					home = contents
+				}
+			}
+
+			installation, ok := Inspect(home)
+			if !ok {
+				continue
+			}
+			// Evita i duplicati dovuti ai collegamenti simbolici (es. "current" di SDKMAN!)
+			resolved, err := filepath.EvalSymlinks(home)
+			if err != nil || seen[resolved] {
+				continue
+			}
+			seen[resolved] = true
+			installations = append(installations, installation)
+		}
+	}
+
+	sort.Slice(installations, func(i, j int) bool {
+		return installations[i].Home < installations[j].Home
+	})
+	return installations
+}
+
+// Inspect legge la versione del JDK nella directory indicata dal suo file release.
+// Restituisce false se la directory non contiene un JDK.
+func Inspect(home string) (Installation, bool) {
+	if !isFile(filepath.Join(home, "bin", javaExecutable())) {
+		return Installation{}, false
+	}
+
+	file, err := os.Open(filepath.Join(home, "release"))
+	if err != nil {
+		return Installation{}, false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, found := strings.CutPrefix(scanner.Text(), "JAVA_VERSION="); found {
+			version := strings.Trim(value, `"`)
+			return Installation{Home: home, Version: version, Major: MajorVersion(version)}, true
+		}
+	}
+	return Installation{}, false
+}
+
+// javaExecutable restituisce il nome dell'eseguibile java per il sistema operativo corrente
+func javaExecutable() string {
+	if runtime.GOOS == "windows" {
+		return "java.exe"
+	}
+	return "java"
+}
+
+// isFile indica se il percorso esiste ed è un file
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// isDir indica se il percorso esiste ed è una directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
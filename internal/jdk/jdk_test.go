@@ -0,0 +1,60 @@
+package jdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMajorVersion(t *testing.T) {
+	cases := map[string]int{
+		"21":                      21,
+		"1.8":                     8,
+		"17.0.9-tem":              17,
+		"temurin-21.0.2+13.0.LTS": 21,
+		"latest":                  0,
+	}
+	for version, expected := range cases {
+		if got := MajorVersion(version); got != expected {
+			t.Errorf("MajorVersion(%q): atteso %d, ottenuto %d", version, expected, got)
+		}
+	}
+}
+
+func TestDetectRequirement(t *testing.T) {
+	write := func(dir, name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sdkman := t.TempDir()
+	write(sdkman, ".sdkmanrc", "# JDK del progetto\njava=11.0.22-tem\n")
+	write(sdkman, ".java-version", "17\n")
+	if req, ok := DetectRequirement(sdkman); !ok || req.Major != 11 || req.Source != ".sdkmanrc" || req.Identifier != "11.0.22-tem" {
+		t.Errorf(".sdkmanrc non riconosciuto: %+v", req)
+	}
+
+	toolVersions := t.TempDir()
+	write(toolVersions, ".tool-versions", "nodejs 20.11.0\njava temurin-21.0.2+13.0.LTS temurin-17.0.10+7\n")
+	if req, ok := DetectRequirement(toolVersions); !ok || req.Major != 21 || req.Identifier != "temurin-21.0.2+13.0.LTS" {
+		t.Errorf(".tool-versions non riconosciuto: %+v", req)
+	}
+
+	pom := t.TempDir()
+	write(pom, "pom.xml", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <properties>
+    <java.version>17</java.version>
+    <maven.compiler.release>${java.version}</maven.compiler.release>
+  </properties>
+</project>`)
+	if req, ok := DetectRequirement(pom); !ok || req.Major != 17 || req.Source != "pom.xml" {
+		t.Errorf("maven.compiler.release non riconosciuta: %+v", req)
+	}
+
+	if req, ok := DetectRequirement(t.TempDir()); ok {
+		t.Errorf("Nessuna versione attesa, ottenuto %+v", req)
+	}
+}
@@ -0,0 +1,91 @@
+package jdk
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Settings è la configurazione dei JDK di un profilo
+type Settings struct {
+	DefaultHome  string            // JAVA_HOME dei progetti che non dichiarano una versione (vuoto = ambiente corrente)
+	ProjectHomes map[string]string // JAVA_HOME per progetto, prioritaria sulla versione dichiarata dal progetto
+	Homes        map[string]string // JAVA_HOME per versione principale (es. "11"), prioritaria sui JDK trovati
+}
+
+// Selection è il JDK scelto per un progetto
+type Selection struct {
+	Home   string // JAVA_HOME da usare (vuoto = quella dell'ambiente corrente)
+	Source string // Motivo della scelta, mostrato all'utente
+}
+
+// Resolver sceglie il JDK di ogni progetto. I JDK installati vengono cercati una sola volta,
+// alla prima richiesta; è sicuro per l'uso concorrente.
+type Resolver struct {
+	settings      Settings
+	once          sync.Once
+	installations []Installation
+}
+
+// NewResolver crea un resolver con la configurazione indicata
+func NewResolver(settings Settings) *Resolver {
+	return &Resolver{settings: settings}
+}
+
+// Resolve sceglie il JDK del progetto nella directory indicata. L'ordine di priorità è:
+// JAVA_HOME configurata per il progetto, versione dichiarata dal progetto (.sdkmanrc, .java-version,
+// .tool-versions, pom.xml), JAVA_HOME del profilo, ambiente corrente.
+// Se nessun JDK installato soddisfa la versione dichiarata restituisce la scelta di ripiego e un errore.
+func (r *Resolver) Resolve(projectName, projectDir string) (Selection, error) {
+	if home := r.settings.ProjectHomes[projectName]; home != "" {
+		return configured(home, "configurazione del progetto")
+	}
+
+	fallback := Selection{Source: "ambiente corrente"}
+	if r.settings.DefaultHome != "" {
+		fallback = Selection{Home: r.settings.DefaultHome, Source: "configurazione del profilo"}
+	}
+
+	requirement, found := DetectRequirement(projectDir)
+	if !found {
+		if fallback.Home != "" {
+			return configured(fallback.Home, fallback.Source)
+		}
+		return fallback, nil
+	}
+
+	source := fmt.Sprintf("Java %s da %s", requirement.Version, requirement.Source)
+	if home := r.settings.Homes[strconv.Itoa(requirement.Major)]; home != "" {
+		return configured(home, source)
+	}
+
+	// SDKMAN! e asdf installano ogni JDK in una directory con il nome dell'identificatore
+	if requirement.Identifier != "" {
+		for _, dir := range []string{sdkmanCandidatesDir(), asdfInstallsDir()} {
+			if installation, ok := Inspect(filepath.Join(dir, requirement.Identifier)); ok {
+				return Selection{Home: installation.Home, Source: source}, nil
+			}
+		}
+	}
+
+	r.once.Do(func() {
+		r.installations = Discover()
+	})
+	for _, installation := range r.installations {
+		if installation.Major == requirement.Major {
+			return Selection{Home: installation.Home, Source: source}, nil
+		}
+	}
+
+	return fallback, fmt.Errorf("nessun JDK %d installato (richiesto da %s): configura la sua JAVA_HOME in java_homes",
+		requirement.Major, requirement.Source)
+}
+
+// configured verifica che la JAVA_HOME configurata contenga un JDK
+func configured(home, source string) (Selection, error) {
+	if !isFile(filepath.Join(home, "bin", javaExecutable())) {
+		return Selection{}, fmt.Errorf("JAVA_HOME '%s' non valida: eseguibile java non trovato", home)
+	}
+	return Selection{Home: home, Source: source}, nil
+}
@@ -0,0 +1,37 @@
+package maven
+
+import (
+	"path/filepath"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+)
+
+// AnalyzeDependencies analizza i progetti Maven selezionati e costruisce il grafo delle dipendenze
+// registrando l'origine di ogni arco. Il grafo contiene archi di tutti i tipi: usa Filter
+// per limitarlo ai tipi da considerare nell'ordinamento.
+func AnalyzeDependencies(projectNames []string, rootPath string) (*buildsystem.DependencyAnalysis, error) {
+	return buildsystem.Analyze(projectNames, buildsystem.AnalyzeOptions{
+		ProjectDir: func(projectName string) string {
+			return filepath.Join(rootPath, projectName)
+		},
+		Systems: []buildsystem.BuildSystem{BuildSystem{}},
+	})
+}
+
+// BuildDependencyGraph costruisce il grafo delle dipendenze tra i progetti Maven selezionati
+// considerando i tipi di arco predefiniti (buildsystem.DefaultEdgeKinds)
+func BuildDependencyGraph(projectNames []string, rootPath string) (map[string][]string, error) {
+	analysis, err := AnalyzeDependencies(projectNames, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	return analysis.Filter(buildsystem.DefaultEdgeKinds).Graph, nil
+}
+
+// TopologicalSort ordina i progetti in base alle loro dipendenze
+// Restituisce l'ordine di esecuzione corretto o un errore se ci sono cicli
+func TopologicalSort(graphMap map[string][]string) ([]string, error) {
+	dependencyGraph := graph.DependencyGraph(graphMap)
+	return dependencyGraph.TopologicalSort()
+}
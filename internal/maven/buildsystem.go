@@ -0,0 +1,84 @@
+package maven
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+const (
+	// Name è il nome del build system Maven
+	Name = "maven"
+	// ProjectFile è il file che identifica un progetto Maven
+	ProjectFile = "pom.xml"
+)
+
+// BuildSystem implementa buildsystem.BuildSystem per i progetti Maven
+type BuildSystem struct {
+	Launcher   Launcher    // Scelta dell'eseguibile Maven (wrapper, PATH o installazione configurata)
+	PhaseRules []PhaseRule // Regole aggiuntive per riconoscere le fasi, valutate prima di quelle predefinite
+	locate     pomLocator  // Risolve i parent tra i progetti gestiti (impostato da ForProjects)
+}
+
+// Name implementa buildsystem.BuildSystem
+func (BuildSystem) Name() string {
+	return Name
+}
+
+// Detect implementa buildsystem.BuildSystem: un progetto Maven contiene un pom.xml
+func (BuildSystem) Detect(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ProjectFile))
+	return err == nil && !info.IsDir()
+}
+
+// ParseProject implementa buildsystem.BuildSystem
+func (b BuildSystem) ParseProject(projectName, dir string) (*buildsystem.Project, error) {
+	return parseProject(projectName, dir, b.locate)
+}
+
+// ForProjects implementa buildsystem.ProjectSetAware: indicizza i pom.xml dei progetti analizzati,
+// così i parent che si trovano in un altro progetto gestito vengono risolti anche senza <relativePath>
+func (b BuildSystem) ForProjects(dirs []string) buildsystem.BuildSystem {
+	index := make(pomIndex)
+	for _, dir := range dirs {
+		index.add(filepath.Join(dir, ProjectFile))
+	}
+	b.locate = index.locate
+	return b
+}
+
+// RegisterArtifacts implementa buildsystem.BuildSystem registrando il progetto e i suoi sub-module
+func (BuildSystem) RegisterArtifacts(project *buildsystem.Project, registry *buildsystem.ArtifactRegistry) {
+	registry.Register(project.Identifier, project.Name)
+
+	// Ignora errori di registrazione dei sub-module: alcuni potrebbero non essere accessibili
+	_ = RegisterSubModules(project.Path, project.Name, registry)
+}
+
+// BuildCommand implementa buildsystem.BuildSystem: esegue i goal in modalità batch sul pom.xml del progetto
+func (b BuildSystem) BuildCommand(dir string, goals []string, opts buildsystem.BuildOptions) buildsystem.Command {
+	args := []string{"-B", "-f", filepath.Join(dir, ProjectFile)}
+	args = append(args, goals...)
+	args = append(args, opts.Args...)
+
+	if opts.SkipTests {
+		args = append(args, "-DskipTests=true")
+	}
+	return buildsystem.Command{
+		Executable: b.Launcher.Resolve(dir),
+		Args:       args,
+		Env:        []string{"MAVEN_OPTS=-Djansi.force=true"}, // Disabilita il buffering di Maven per l'output in tempo reale
+	}
+}
+
+// OutputParser implementa buildsystem.BuildSystem riconoscendo le fasi con le regole configurate
+func (b BuildSystem) OutputParser() executor.OutputParser {
+	return &outputParser{phaseRules: b.PhaseRules, diagnostics: newDiagnosticCollector()}
+}
+
+// UsesJDK implementa buildsystem.BuildSystem
+func (BuildSystem) UsesJDK() bool {
+	return true
+}
@@ -1,7 +1,15 @@
 package maven
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
 )
 
 // TestTopologicalSort verifica l'ordinamento topologico di un grafo di dipendenze
@@ -40,7 +48,7 @@ func TestTopologicalSort(t *testing.T) {
 				"projectC": {"projectA", "projectB"},
 				"projectD": {"projectC"},
 			},
-			expectedOrder: nil, // L'ordine esatto dipende dall'implementazione, ma A e B devono venire prima di C
+			expectedOrder: []string{"projectA", "projectB", "projectC", "projectD"},
 			expectError:   false,
 		},
 		{
@@ -75,6 +83,11 @@ func TestTopologicalSort(t *testing.T) {
 				return
 			}
 
+			// L'ordine è deterministico: se specificato deve coincidere esattamente
+			if tt.expectedOrder != nil && !reflect.DeepEqual(result, tt.expectedOrder) {
+				t.Errorf("Expected order %v, got %v", tt.expectedOrder, result)
+			}
+
 			// Verifica che le dipendenze siano rispettate
 			position := make(map[string]int)
 			for i, proj := range result {
@@ -93,3 +106,263 @@ func TestTopologicalSort(t *testing.T) {
 		})
 	}
 }
+
+// writePom crea un pom.xml minimale per un progetto di test
+func writePom(t *testing.T, root, name, content string) string {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	pomPath := filepath.Join(dir, "pom.xml")
+	if err := os.WriteFile(pomPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return pomPath
+}
+
+// TestAnalyzeDependenciesCycle verifica che un ciclo riporti il pom.xml e la dipendenza di ogni arco
+func TestAnalyzeDependenciesCycle(t *testing.T) {
+	root := t.TempDir()
+	pomA := writePom(t, root, "a", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>a</artifactId>
+  <dependencies>
+    <dependency><groupId>com.example</groupId><artifactId>b</artifactId></dependency>
+  </dependencies>
+</project>`)
+	pomB := writePom(t, root, "b", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>b</artifactId>
+  <dependencies>
+    <dependency><groupId>com.example</groupId><artifactId>a</artifactId></dependency>
+  </dependencies>
+</project>`)
+
+	analysis, err := AnalyzeDependencies([]string{"a", "b"}, root)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = analysis.TopologicalSort(nil)
+	var cycleErr *buildsystem.CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected *buildsystem.CycleError, got %v", err)
+	}
+
+	var graphCycleErr *graph.CycleError
+	if !errors.As(err, &graphCycleErr) {
+		t.Errorf("Expected error to unwrap to *graph.CycleError")
+	}
+
+	msg := cycleErr.Error()
+	for _, expected := range []string{
+		"a -> b -> a",
+		"a -> b: " + pomA + " dichiara com.example:b",
+		"b -> a: " + pomB + " dichiara com.example:a",
+	} {
+		if !strings.Contains(msg, expected) {
+			t.Errorf("Expected error message to contain %q, got:\n%s", expected, msg)
+		}
+	}
+}
+
+// TestAnalyzeDependenciesInterpolation verifica la risoluzione delle espressioni ${...} nei pom.xml
+func TestAnalyzeDependenciesInterpolation(t *testing.T) {
+	root := t.TempDir()
+
+	// Parent aggregatore nella root: definisce una proprietà ereditata dai progetti
+	writePom(t, root, "", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>workspace</artifactId>
+  <version>1.0.0</version>
+  <properties>
+    <libs.group>com.example.libs</libs.group>
+  </properties>
+</project>`)
+	writePom(t, root, "core", `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>workspace</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <groupId>${libs.group}</groupId>
+  <artifactId>core</artifactId>
+</project>`)
+	writePom(t, root, "util", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>util</artifactId>
+</project>`)
+	pomApp := writePom(t, root, "app", `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>workspace</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <properties>
+    <my.group>${project.groupId}</my.group>
+  </properties>
+  <dependencies>
+    <dependency><groupId>${libs.group}</groupId><artifactId>core</artifactId></dependency>
+    <dependency><groupId>${my.group}</groupId><artifactId>util</artifactId></dependency>
+    <dependency><groupId>${missing.group}</groupId><artifactId>other</artifactId></dependency>
+  </dependencies>
+</project>`)
+
+	analysis, err := AnalyzeDependencies([]string{"app", "core", "util"}, root)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"core", "util"}
+	if !reflect.DeepEqual(analysis.Graph["app"], expected) {
+		t.Errorf("Expected app dependencies %v, got %v", expected, analysis.Graph["app"])
+	}
+
+	if len(analysis.Warnings) != 1 || !strings.Contains(analysis.Warnings[0], pomApp) ||
+		!strings.Contains(analysis.Warnings[0], "${missing.group}") {
+		t.Errorf("Expected one warning for ${missing.group} in %s, got %v", pomApp, analysis.Warnings)
+	}
+}
+
+// TestAnalyzeDependenciesParentProject verifica che un parent gestito in un altro progetto
+// diventi un arco del grafo e che le sue dipendenze vengano ereditate dal figlio
+func TestAnalyzeDependenciesParentProject(t *testing.T) {
+	root := t.TempDir()
+	pomParent := writePom(t, root, "company-parent", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>company-parent</artifactId>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency><groupId>com.example</groupId><artifactId>lib</artifactId></dependency>
+  </dependencies>
+</project>`)
+	writePom(t, root, "lib", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>lib</artifactId>
+</project>`)
+	writePom(t, root, "service", `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>company-parent</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <artifactId>service</artifactId>
+</project>`)
+	writePom(t, root, "legacy", `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>company-parent</artifactId>
+    <version>1.0.0</version>
+    <relativePath>../company-parent</relativePath>
+  </parent>
+  <artifactId>legacy</artifactId>
+</project>`)
+
+	t.Run("Parent tra i progetti selezionati", func(t *testing.T) {
+		analysis, err := AnalyzeDependencies([]string{"company-parent", "lib", "service"}, root)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := []string{"company-parent", "lib"}
+		if !reflect.DeepEqual(analysis.Graph["service"], expected) {
+			t.Errorf("Expected service dependencies %v, got %v", expected, analysis.Graph["service"])
+		}
+
+		origins := analysis.Origins[graph.Edge{From: "service", To: "lib"}]
+		if len(origins) != 1 || origins[0].Source != pomParent {
+			t.Errorf("Expected service -> lib to originate from %s, got %v", pomParent, origins)
+		}
+	})
+
+	t.Run("Parent risolto tramite relativePath", func(t *testing.T) {
+		analysis, err := AnalyzeDependencies([]string{"lib", "legacy"}, root)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := []string{"lib"}
+		if !reflect.DeepEqual(analysis.Graph["legacy"], expected) {
+			t.Errorf("Expected legacy dependencies %v, got %v", expected, analysis.Graph["legacy"])
+		}
+	})
+}
+
+// TestAnalyzeDependenciesEdgeKinds verifica che BOM importati, plugin ed estensioni
+// generino archi etichettati e filtrabili per tipo
+func TestAnalyzeDependenciesEdgeKinds(t *testing.T) {
+	root := t.TempDir()
+	writePom(t, root, "platform-bom", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>platform-bom</artifactId>
+</project>`)
+	writePom(t, root, "codegen-plugin", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>codegen-maven-plugin</artifactId>
+</project>`)
+	writePom(t, root, "rules", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>rules</artifactId>
+</project>`)
+	writePom(t, root, "build-ext", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>build-ext</artifactId>
+</project>`)
+	writePom(t, root, "service", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>service</artifactId>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.example</groupId><artifactId>platform-bom</artifactId>
+        <type>pom</type><scope>import</scope>
+      </dependency>
+      <dependency><groupId>com.example</groupId><artifactId>rules</artifactId></dependency>
+    </dependencies>
+  </dependencyManagement>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>com.example</groupId><artifactId>codegen-maven-plugin</artifactId>
+        <dependencies>
+          <dependency><groupId>com.example</groupId><artifactId>rules</artifactId></dependency>
+        </dependencies>
+      </plugin>
+    </plugins>
+    <extensions>
+      <extension><groupId>com.example</groupId><artifactId>build-ext</artifactId></extension>
+    </extensions>
+  </build>
+</project>`)
+
+	analysis, err := AnalyzeDependencies([]string{"build-ext", "codegen-plugin", "platform-bom", "rules", "service"}, root)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"build-ext", "codegen-plugin", "platform-bom", "rules"}
+	if !reflect.DeepEqual(analysis.Graph["service"], expected) {
+		t.Errorf("Expected service dependencies %v, got %v", expected, analysis.Graph["service"])
+	}
+
+	kinds := map[string]buildsystem.DependencyKind{
+		"platform-bom":   buildsystem.KindImport,
+		"codegen-plugin": buildsystem.KindPlugin,
+		"rules":          buildsystem.KindPlugin,
+		"build-ext":      buildsystem.KindExtension,
+	}
+	for dep, kind := range kinds {
+		got := analysis.EdgeKinds(graph.Edge{From: "service", To: dep})
+		if !reflect.DeepEqual(got, []buildsystem.DependencyKind{kind}) {
+			t.Errorf("Expected service -> %s to be labeled %v, got %v", dep, kind, got)
+		}
+	}
+
+	// Con i tipi predefiniti restano solo i BOM importati
+	filtered := analysis.Filter(buildsystem.DefaultEdgeKinds)
+	if !reflect.DeepEqual(filtered.Graph["service"], []string{"platform-bom"}) {
+		t.Errorf("Expected filtered service dependencies [platform-bom], got %v", filtered.Graph["service"])
+	}
+}
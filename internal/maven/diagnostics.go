@@ -0,0 +1,113 @@
+package maven
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+// Pattern per il riconoscimento dei problemi nell'output Maven
+var (
+	// Esempio: [ERROR] /src/main/java/com/example/App.java:[12,8] cannot find symbol
+	javacErrorPattern = regexp.MustCompile(`^\[ERROR] (\S+):\[(\d+)(?:,(\d+))?] (.+)$`)
+
+	// Esempio: [ERROR] e: file:///src/main/kotlin/App.kt:12:5 Unresolved reference: foo
+	kotlinErrorPattern = regexp.MustCompile(`^\[ERROR] e: (?:file://)?(\S+?):(\d+):(\d+) (.+)$`)
+
+	// Esempio: [ERROR] com.example.AppTest.shouldWork  Time elapsed: 0.01 s  <<< FAILURE!
+	// Esempio: [ERROR] shouldWork(com.example.AppTest)  Time elapsed: 0.01 s  <<< ERROR!
+	testFailurePattern = regexp.MustCompile(`^\[ERROR] (\S+)\s+Time elapsed: .*<<< (FAILURE|ERROR)!`)
+
+	// Esempio: [ERROR] Failed to execute goal on project demo: Could not resolve dependencies for project ...
+	dependencyErrorPattern = regexp.MustCompile(`(Could not resolve dependencies.*|Failed to collect dependencies.*|` +
+		`Could not find artifact.*|Could not transfer artifact.*|Non-resolvable (?:parent|import) POM.*)$`)
+
+	// Esempio: [ERROR] Rule 0: org.apache.maven.enforcer.rules.version.RequireJavaVersion failed with message:
+	enforcerRulePattern = regexp.MustCompile(`^\[(?:ERROR|WARNING)] Rule \d+: (\S+) failed with message:\s*(.*)$`)
+
+	// Prefisso dei livelli di log Maven ([INFO], [ERROR], ...)
+	logLevelPattern = regexp.MustCompile(`^\[[A-Z]+]`)
+)
+
+// diagnosticCollector riconosce i problemi riga per riga nell'output Maven.
+// Alcuni messaggi occupano più righe: pending indica il problema a cui attaccare
+// la prima riga di testo successiva.
+type diagnosticCollector struct {
+	diagnostics executor.DiagnosticList
+	pending     int                     // Indice del problema in attesa del messaggio (-1 = nessuno)
+	pendingKind executor.DiagnosticKind // Categoria del problema in attesa del messaggio
+}
+
+// newDiagnosticCollector crea un collector vuoto
+func newDiagnosticCollector() *diagnosticCollector {
+	return &diagnosticCollector{pending: -1}
+}
+
+// process analizza una riga dell'output Maven
+func (c *diagnosticCollector) process(line string) {
+	line = strings.TrimSpace(executor.StripANSI(line))
+	if line == "" {
+		return
+	}
+
+	// Riga di continuazione: il messaggio del test fallito o della regola violata
+	if c.pending >= 0 {
+		pending := c.pending
+		c.pending = -1
+		if !logLevelPattern.MatchString(line) {
+			c.diagnostics.SetMessage(pending, line)
+			return
+		}
+		if message, ok := strings.CutPrefix(line, "[ERROR] "); ok && c.pendingKind == executor.DiagnosticEnforcer {
+			c.diagnostics.SetMessage(pending, message)
+			return
+		}
+	}
+
+	if matches := javacErrorPattern.FindStringSubmatch(line); matches != nil {
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		c.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticCompilation, File: matches[1],
+			Line: lineNumber, Column: column, Message: matches[4]})
+		return
+	}
+
+	if matches := kotlinErrorPattern.FindStringSubmatch(line); matches != nil {
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		c.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticCompilation, File: matches[1],
+			Line: lineNumber, Column: column, Message: matches[4]})
+		return
+	}
+
+	if matches := testFailurePattern.FindStringSubmatch(line); matches != nil && matches[1] != "Tests" {
+		c.addPending(executor.Diagnostic{Kind: executor.DiagnosticTest, Test: matches[1]})
+		return
+	}
+
+	if matches := enforcerRulePattern.FindStringSubmatch(line); matches != nil {
+		diagnostic := executor.Diagnostic{Kind: executor.DiagnosticEnforcer, Rule: matches[1], Message: matches[2]}
+		if matches[2] == "" {
+			c.addPending(diagnostic)
+		} else {
+			c.diagnostics.Add(diagnostic)
+		}
+		return
+	}
+
+	if strings.HasPrefix(line, "[ERROR]") {
+		if matches := dependencyErrorPattern.FindStringSubmatch(line); matches != nil {
+			c.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticDependency, Message: matches[1]})
+		}
+	}
+}
+
+// addPending registra un problema il cui messaggio si trova sulla riga successiva
+func (c *diagnosticCollector) addPending(diagnostic executor.Diagnostic) {
+	if c.diagnostics.Add(diagnostic) {
+		c.pending = c.diagnostics.Len() - 1
+		c.pendingKind = diagnostic.Kind
+	}
+}
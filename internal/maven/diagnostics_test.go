@@ -0,0 +1,42 @@
+package maven
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+func TestDiagnosticCollector(t *testing.T) {
+	output := []string{
+		"[INFO] --- compiler:3.14.1:compile (default-compile) @ demo ---",
+		"[ERROR] /src/main/java/com/example/App.java:[12,8] cannot find symbol",
+		"[ERROR] e: file:///src/main/kotlin/Util.kt:3:5 Unresolved reference: foo",
+		"[ERROR] com.example.AppTest.shouldWork  Time elapsed: 0.01 s  <<< FAILURE!",
+		"org.opentest4j.AssertionFailedError: expected: <1> but was: <2>",
+		"\tat com.example.AppTest.shouldWork(AppTest.java:10)",
+		"[ERROR] Tests run: 2, Failures: 1, Errors: 0, Skipped: 0, Time elapsed: 0.02 s <<< FAILURE! - in com.example.AppTest",
+		"[ERROR] Rule 0: org.apache.maven.enforcer.rules.version.RequireJavaVersion failed with message:",
+		"[ERROR] Detected JDK version 11 is not in the allowed range [17,).",
+		"[ERROR] Failed to execute goal on project demo: Could not resolve dependencies for project com.example:demo:jar:1.0: missing lib",
+		// Il riepilogo finale di Maven ripete gli errori di compilazione
+		"[ERROR] /src/main/java/com/example/App.java:[12,8] cannot find symbol",
+	}
+
+	collector := newDiagnosticCollector()
+	for _, line := range output {
+		collector.process(line)
+	}
+
+	expected := []executor.Diagnostic{
+		{Kind: executor.DiagnosticCompilation, File: "/src/main/java/com/example/App.java", Line: 12, Column: 8, Message: "cannot find symbol"},
+		{Kind: executor.DiagnosticCompilation, File: "/src/main/kotlin/Util.kt", Line: 3, Column: 5, Message: "Unresolved reference: foo"},
+		{Kind: executor.DiagnosticTest, Test: "com.example.AppTest.shouldWork", Message: "org.opentest4j.AssertionFailedError: expected: <1> but was: <2>"},
+		{Kind: executor.DiagnosticEnforcer, Rule: "org.apache.maven.enforcer.rules.version.RequireJavaVersion", Message: "Detected JDK version 11 is not in the allowed range [17,)."},
+		{Kind: executor.DiagnosticDependency, Message: "Could not resolve dependencies for project com.example:demo:jar:1.0: missing lib"},
+	}
+
+	if diagnostics := collector.diagnostics.Items(); !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Diagnostiche errate:\natteso  %+v\nottenuto %+v", expected, diagnostics)
+	}
+}
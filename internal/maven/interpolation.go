@@ -0,0 +1,187 @@
+package maven
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxInterpolationDepth limita la risoluzione di proprietà che fanno riferimento ad altre proprietà
+const maxInterpolationDepth = 10
+
+// placeholderPattern riconosce le espressioni Maven nella forma ${nome.proprieta}
+var placeholderPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// pomProperties rappresenta la sezione <properties> di un pom.xml, i cui elementi hanno nomi arbitrari
+type pomProperties struct {
+	Entries []pomProperty `xml:",any"`
+}
+
+type pomProperty struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// propertyResolver risolve le espressioni ${...} di un pom a partire da una mappa di proprietà
+type propertyResolver struct {
+	properties map[string]string
+}
+
+// interpolate sostituisce le espressioni ${...} presenti in value.
+// Restituisce il valore risolto e i nomi delle proprietà che non è stato possibile risolvere.
+func (r propertyResolver) interpolate(value string) (string, []string) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	unresolved := make([]string, 0)
+	for depth := 0; depth < maxInterpolationDepth && strings.Contains(value, "${"); depth++ {
+		unresolved = unresolved[:0]
+		changed := false
+		value = placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+			name := match[2 : len(match)-1]
+			if resolved, ok := r.lookup(name); ok {
+				changed = true
+				return resolved
+			}
+			unresolved = append(unresolved, name)
+			return match
+		})
+		if !changed {
+			break
+		}
+	}
+
+	return value, unresolved
+}
+
+// lookup cerca una proprietà: prima tra quelle del pom (e dei parent), poi tra le variabili d'ambiente
+func (r propertyResolver) lookup(name string) (string, bool) {
+	if value, ok := r.properties[name]; ok {
+		return value, true
+	}
+	if envName, isEnv := strings.CutPrefix(name, "env."); isEnv {
+		return os.LookupEnv(envName)
+	}
+	return "", false
+}
+
+// interpolatePom risolve le espressioni ${...} nelle coordinate del pom e delle sue dipendenze.
+// Le proprietà disponibili sono quelle della catena dei parent, quelle del pom stesso
+// e le built-in project.*, pom.* e parent.*.
+func interpolatePom(p *pom, pomPath string, inherited map[string]string) {
+	// Proprietà utente: quelle ereditate dai parent, sovrascritte da quelle del pom
+	p.properties = make(map[string]string, len(inherited))
+	for name, value := range inherited {
+		p.properties[name] = value
+	}
+	if p.Properties != nil {
+		for _, entry := range p.Properties.Entries {
+			p.properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+		}
+	}
+
+	resolver := propertyResolver{properties: make(map[string]string, len(p.properties)+16)}
+	for name, value := range p.properties {
+		resolver.properties[name] = value
+	}
+	addBuiltinProperties(resolver.properties, p, pomPath)
+
+	field := func(value string) string {
+		resolved, unresolved := resolver.interpolate(value)
+		for _, name := range unresolved {
+			p.addWarning(fmt.Sprintf("%s: proprietà non risolta ${%s} in '%s'", pomPath, name, value))
+		}
+		return resolved
+	}
+
+	p.GroupId = field(p.GroupId)
+	p.ArtifactId = field(p.ArtifactId)
+	p.Version = field(p.Version)
+
+	interpolateDeps := func(deps *pomDeps) {
+		if deps == nil {
+			return
+		}
+		for i := range deps.Dependency {
+			dep := &deps.Dependency[i]
+			dep.GroupId = field(dep.GroupId)
+			dep.ArtifactId = field(dep.ArtifactId)
+			dep.Version = field(dep.Version)
+			dep.Type = field(dep.Type)
+			dep.Scope = field(dep.Scope)
+		}
+	}
+
+	interpolateDeps(p.Dependencies)
+	if p.DependencyManagement != nil {
+		interpolateDeps(p.DependencyManagement.Dependencies)
+	}
+
+	if p.Build != nil {
+		if p.Build.Plugins != nil {
+			for i := range p.Build.Plugins.Plugin {
+				plugin := &p.Build.Plugins.Plugin[i]
+				plugin.GroupId = field(plugin.GroupId)
+				plugin.ArtifactId = field(plugin.ArtifactId)
+				plugin.Version = field(plugin.Version)
+				interpolateDeps(plugin.Dependencies)
+			}
+		}
+		if p.Build.Extensions != nil {
+			for i := range p.Build.Extensions.Extension {
+				extension := &p.Build.Extensions.Extension[i]
+				extension.GroupId = field(extension.GroupId)
+				extension.ArtifactId = field(extension.ArtifactId)
+				extension.Version = field(extension.Version)
+			}
+		}
+	}
+}
+
+// addBuiltinProperties aggiunge le proprietà built-in di Maven relative al pom e al suo parent
+func addBuiltinProperties(properties map[string]string, p *pom, pomPath string) {
+	set := func(name, value string) {
+		if value == "" {
+			return
+		}
+		properties["project."+name] = value
+		properties["pom."+name] = value // Forma deprecata ma ancora diffusa
+	}
+
+	set("groupId", p.GroupId)
+	set("artifactId", p.ArtifactId)
+	set("version", p.Version)
+	set("basedir", filepath.Dir(pomPath))
+
+	if p.Parent != nil {
+		set("parent.groupId", p.Parent.GroupId)
+		set("parent.artifactId", p.Parent.ArtifactId)
+		set("parent.version", p.Parent.Version)
+		properties["parent.groupId"] = p.Parent.GroupId
+		properties["parent.artifactId"] = p.Parent.ArtifactId
+		properties["parent.version"] = p.Parent.Version
+	}
+}
+
+// parentPomPath restituisce il percorso del pom.xml del parent indicato da <relativePath>
+// (default ../pom.xml). Restituisce una stringa vuota se il lookup locale è disabilitato.
+func parentPomPath(pomPath string, parent *pomParent) string {
+	if parent.RelativePath != nil && strings.TrimSpace(*parent.RelativePath) == "" {
+		return "" // <relativePath/> disabilita la ricerca del parent nel filesystem
+	}
+
+	relative := "../pom.xml"
+	if parent.RelativePath != nil {
+		relative = strings.TrimSpace(*parent.RelativePath)
+	}
+
+	candidate := filepath.Join(filepath.Dir(pomPath), filepath.FromSlash(relative))
+	if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+		candidate = filepath.Join(candidate, "pom.xml")
+	}
+	return candidate
+}
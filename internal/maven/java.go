@@ -0,0 +1,32 @@
+package maven
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// javaVersionProperties sono le proprietà del pom che indicano la versione Java del progetto,
+// in ordine di priorità (java.version è la convenzione dei progetti Spring Boot)
+var javaVersionProperties = []string{"maven.compiler.release", "java.version"}
+
+// JavaRelease restituisce la versione Java dichiarata nel pom.xml del progetto (o dei suoi parent)
+// tramite maven.compiler.release o java.version. Restituisce una stringa vuota se non è dichiarata
+// o non è risolvibile.
+func JavaRelease(projectPath string) string {
+	p, err := parsePomFile(filepath.Join(projectPath, "pom.xml"))
+	if err != nil {
+		return ""
+	}
+
+	resolver := propertyResolver{properties: p.properties}
+	for _, name := range javaVersionProperties {
+		value, found := p.properties[name]
+		if !found {
+			continue
+		}
+		if resolved, unresolved := resolver.interpolate(value); len(unresolved) == 0 && strings.TrimSpace(resolved) != "" {
+			return strings.TrimSpace(resolved)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package maven
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// systemMaven è l'eseguibile Maven cercato nel PATH
+const systemMaven = "mvn"
+
+// Launcher determina l'eseguibile Maven da usare per un progetto.
+// Per default usa il Maven Wrapper (mvnw) se il progetto lo fornisce, altrimenti Maven dal PATH.
+type Launcher struct {
+	SystemOnly bool   // Ignora il Maven Wrapper e usa sempre Maven dal PATH
+	Home       string // Installazione Maven da usare al posto del wrapper e del PATH (es. /opt/maven-3.9)
+}
+
+// Validate verifica che la Maven home configurata contenga l'eseguibile Maven
+func (l Launcher) Validate() error {
+	if l.Home != "" && !isExecutable(l.homeExecutable()) {
+		return fmt.Errorf("eseguibile Maven non trovato o non eseguibile nella Maven home '%s'", l.Home)
+	}
+	return nil
+}
+
+// Resolve restituisce l'eseguibile Maven per il progetto nella directory indicata.
+// Un wrapper senza permesso di esecuzione viene ignorato a favore di Maven dal PATH.
+func (l Launcher) Resolve(projectDir string) string {
+	if l.Home != "" {
+		return l.homeExecutable()
+	}
+
+	if !l.SystemOnly {
+		if wrapper := filepath.Join(projectDir, platformScript("mvnw")); isExecutable(wrapper) {
+			return wrapper
+		}
+	}
+	return systemMaven
+}
+
+// homeExecutable restituisce l'eseguibile Maven della Maven home configurata
+func (l Launcher) homeExecutable() string {
+	return filepath.Join(l.Home, "bin", platformScript("mvn"))
+}
+
+// platformScript restituisce il nome dello script Maven per il sistema operativo corrente
+func platformScript(name string) string {
+	if runtime.GOOS == "windows" {
+		return name + ".cmd"
+	}
+	return name
+}
+
+// isExecutable indica se il percorso esiste ed è un file eseguibile.
+// Su Windows gli script .cmd non hanno un permesso di esecuzione da verificare.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
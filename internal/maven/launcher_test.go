@@ -0,0 +1,45 @@
+package maven
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestLauncherResolve verifica la scelta tra Maven Wrapper, Maven dal PATH e Maven home
+func TestLauncherResolve(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permessi di esecuzione non verificati su Windows")
+	}
+
+	withWrapper := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withWrapper, "mvnw"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	notExecutable := t.TempDir()
+	if err := os.WriteFile(filepath.Join(notExecutable, "mvnw"), []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		launcher   Launcher
+		projectDir string
+		expected   string
+	}{
+		{"wrapper del progetto", Launcher{}, withWrapper, filepath.Join(withWrapper, "mvnw")},
+		{"wrapper non eseguibile", Launcher{}, notExecutable, systemMaven},
+		{"senza wrapper", Launcher{}, t.TempDir(), systemMaven},
+		{"wrapper ignorato", Launcher{SystemOnly: true}, withWrapper, systemMaven},
+		{"maven home", Launcher{Home: "/opt/maven"}, withWrapper, filepath.Join("/opt/maven", "bin", "mvn")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.launcher.Resolve(tt.projectDir); got != tt.expected {
+				t.Errorf("Resolve() = %s, atteso %s", got, tt.expected)
+			}
+		})
+	}
+}
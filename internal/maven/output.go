@@ -0,0 +1,77 @@
+package maven
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+// Pattern per il matching dell'output Maven
+var (
+	// Pattern per rilevare l'esecuzione di un plugin Maven
+	// Esempio: [INFO] --- compiler:3.14.1:compile (default-compile) @ demo-1 ---
+	// Esempio: [INFO] --- spring-boot:3.5.7:repackage (repackage) @ demo-1 ---
+	// Esempio: [INFO] --- maven-failsafe-plugin:3.2.5:integration-test (default) @ demo-1 ---
+	// Cattura: plugin, goal, module (ignoriamo la versione)
+	pluginPattern = regexp.MustCompile(`\[INFO] --- ([a-zA-Z0-9.-]+):([^:]+):([a-zA-Z0-9-]+).*?@\s+(\S+)\s+---`)
+
+	// Pattern per i test in esecuzione
+	// Esempio: [INFO] Running com.example.MyTest
+	testRunningPattern = regexp.MustCompile(`\[INFO] Running (.+)`)
+
+	// Pattern per i risultati dei test, per classe (con "- in") o totali del modulo
+	// Esempio: [INFO] Tests run: 5, Failures: 0, Errors: 0, Skipped: 0, Time elapsed: 0.1 s - in com.example.MyTest
+	// Esempio: [ERROR] Tests run: 5, Failures: 1, Errors: 0, Skipped: 0
+	testResultsPattern = regexp.MustCompile(`\[(?:INFO|WARNING|ERROR)] Tests run: (\d+), Failures: (\d+), Errors: (\d+), Skipped: (\d+)(?:.*? - in (\S+))?`)
+)
+
+// outputParser implementa executor.OutputParser per l'output di Maven in modalità batch
+type outputParser struct {
+	phaseRules  []PhaseRule // Regole aggiuntive per riconoscere le fasi, valutate prima di quelle predefinite
+	diagnostics *diagnosticCollector
+}
+
+// ParseLine implementa executor.OutputParser
+func (p *outputParser) ParseLine(line string) executor.OutputEvent {
+	p.diagnostics.process(line)
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return executor.OutputEvent{}
+	}
+
+	// Inizio di una nuova fase (esecuzione di un plugin)
+	if matches := pluginPattern.FindStringSubmatch(line); matches != nil {
+		// matches[1] = plugin, matches[2] = versione (ignorata), matches[3] = goal, matches[4] = modulo
+		return executor.OutputEvent{
+			Kind:  executor.EventPhaseStarted,
+			Phase: identifyPhase(p.phaseRules, matches[1], matches[3], matches[4]),
+		}
+	}
+
+	// Esecuzione di una classe di test
+	if matches := testRunningPattern.FindStringSubmatch(line); matches != nil {
+		return executor.OutputEvent{Kind: executor.EventTestStarted, Test: matches[1]}
+	}
+
+	// Risultati dei test
+	if matches := testResultsPattern.FindStringSubmatch(line); matches != nil {
+		run, _ := strconv.Atoi(matches[1])
+		failures, _ := strconv.Atoi(matches[2])
+		errors, _ := strconv.Atoi(matches[3])
+		skipped, _ := strconv.Atoi(matches[4])
+		return executor.OutputEvent{
+			Kind:    executor.EventTestResults,
+			Results: executor.TestResults{Suite: matches[5], Run: run, Failures: failures, Errors: errors, Skipped: skipped},
+		}
+	}
+
+	return executor.OutputEvent{}
+}
+
+// Diagnostics implementa executor.OutputParser
+func (p *outputParser) Diagnostics() []executor.Diagnostic {
+	return p.diagnostics.diagnostics.Items()
+}
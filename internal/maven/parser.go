@@ -6,24 +6,34 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
-	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
 )
 
 // pom rappresenta la struttura XML di un file pom.xml (semplificata)
 type pom struct {
-	XMLName      xml.Name    `xml:"project"`
-	GroupId      string      `xml:"groupId"`
-	ArtifactId   string      `xml:"artifactId"`
-	Parent       *pomParent  `xml:"parent"`
-	Dependencies *pomDeps    `xml:"dependencies"`
-	Modules      *pomModules `xml:"modules"`
+	XMLName              xml.Name                 `xml:"project"`
+	GroupId              string                   `xml:"groupId"`
+	ArtifactId           string                   `xml:"artifactId"`
+	Version              string                   `xml:"version"`
+	Parent               *pomParent               `xml:"parent"`
+	Properties           *pomProperties           `xml:"properties"`
+	Dependencies         *pomDeps                 `xml:"dependencies"`
+	DependencyManagement *pomDependencyManagement `xml:"dependencyManagement"`
+	Build                *pomBuild                `xml:"build"`
+	Modules              *pomModules              `xml:"modules"`
+
+	properties            map[string]string        // Proprietà effettive (parent + pom), valorizzate da interpolatePom
+	inheritedDependencies []buildsystem.Dependency // Dipendenze ereditate dalla catena dei parent
+	warnings              []string                 // Avvisi emersi durante l'analisi (es. proprietà non risolte)
 }
 
 type pomParent struct {
-	GroupId    string `xml:"groupId"`
-	ArtifactId string `xml:"artifactId"`
+	GroupId      string  `xml:"groupId"`
+	ArtifactId   string  `xml:"artifactId"`
+	Version      string  `xml:"version"`
+	RelativePath *string `xml:"relativePath"`
 }
 
 type pomDeps struct {
@@ -33,38 +43,87 @@ type pomDeps struct {
 type pomDependency struct {
 	GroupId    string `xml:"groupId"`
 	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Scope      string `xml:"scope"`
+}
+
+type pomDependencyManagement struct {
+	Dependencies *pomDeps `xml:"dependencies"`
+}
+
+type pomBuild struct {
+	Plugins    *pomPlugins    `xml:"plugins"`
+	Extensions *pomExtensions `xml:"extensions"`
+}
+
+type pomPlugins struct {
+	Plugin []pomPlugin `xml:"plugin"`
+}
+
+type pomPlugin struct {
+	GroupId      string   `xml:"groupId"`
+	ArtifactId   string   `xml:"artifactId"`
+	Version      string   `xml:"version"`
+	Dependencies *pomDeps `xml:"dependencies"`
 }
 
+type pomExtensions struct {
+	Extension []pomDependency `xml:"extension"`
+}
+
+// defaultPluginGroupId è il groupId implicito dei plugin Maven dichiarati senza groupId
+const defaultPluginGroupId = "org.apache.maven.plugins"
+
 type pomModules struct {
 	Module []string `xml:"module"`
 }
 
+// addWarning registra un avviso evitando duplicati
+func (p *pom) addWarning(warning string) {
+	if !slices.Contains(p.warnings, warning) {
+		p.warnings = append(p.warnings, warning)
+	}
+}
+
+// pomLocator restituisce il percorso del pom.xml che definisce una coordinata Maven (groupId:artifactId).
+// Permette di risolvere i parent che si trovano in altri progetti gestiti.
+type pomLocator func(identifier string) (string, bool)
+
 // ParseProject analizza un progetto Maven e restituisce le sue informazioni
 func ParseProject(projectName, rootPath string) (*buildsystem.Project, error) {
-	pomPath := filepath.Join(rootPath, projectName, "pom.xml")
+	return parseProject(projectName, filepath.Join(rootPath, projectName), nil)
+}
 
-	pomData, err := parsePomFile(pomPath)
+// parseProject analizza il progetto Maven nella directory projectDir usando locate per risolvere
+// i parent non raggiungibili tramite <relativePath>
+func parseProject(projectName, projectDir string, locate pomLocator) (*buildsystem.Project, error) {
+	pomPath := filepath.Join(projectDir, "pom.xml")
+
+	pomData, err := loadPom(pomPath, locate, make(map[string]bool))
 	if err != nil {
 		return nil, fmt.Errorf("errore analisi progetto %s: %w", projectName, err)
 	}
 
 	project := &buildsystem.Project{
 		Name:         projectName,
-		Path:         filepath.Join(rootPath, projectName),
+		Path:         projectDir,
 		Identifier:   makeIdentifier(pomData.GroupId, pomData.ArtifactId),
-		Dependencies: make([]string, 0),
+		Dependencies: make([]buildsystem.Dependency, 0),
 	}
 
-	// Estrai le dipendenze dirette
-	project.Dependencies = append(project.Dependencies, extractDependencies(pomData)...)
+	// Estrai le dipendenze dirette (incluse quelle ereditate dai parent)
+	project.Dependencies = append(project.Dependencies, extractDependencies(pomData, pomPath)...)
+	project.Warnings = append(project.Warnings, pomData.warnings...)
 
 	// Se il progetto ha moduli, analizza anche quelli per trovare dipendenze
 	if pomData.Modules != nil && len(pomData.Modules.Module) > 0 {
 		for _, module := range pomData.Modules.Module {
 			modulePomPath := filepath.Join(project.Path, module, "pom.xml")
-			moduleDeps, err := extractModuleDependenciesRecursive(modulePomPath)
+			moduleDeps, moduleWarnings, err := extractModuleDependenciesRecursive(modulePomPath, locate)
 			if err == nil {
 				project.Dependencies = append(project.Dependencies, moduleDeps...)
+				project.Warnings = append(project.Warnings, moduleWarnings...)
 			}
 		}
 	}
@@ -72,8 +131,15 @@ func ParseProject(projectName, rootPath string) (*buildsystem.Project, error) {
 	return project, nil
 }
 
-// parsePomFile legge e analizza un file pom.xml
+// parsePomFile legge e analizza un file pom.xml risolvendo le espressioni ${...}
+// con le proprietà del pom, della catena dei parent e le built-in di Maven
 func parsePomFile(pomPath string) (*pom, error) {
+	return loadPom(pomPath, nil, make(map[string]bool))
+}
+
+// loadPom legge un pom.xml, risolve la catena dei parent e ne interpola le proprietà.
+// visited evita cicli nella catena dei parent.
+func loadPom(pomPath string, locate pomLocator, visited map[string]bool) (*pom, error) {
 	data, err := os.ReadFile(pomPath)
 	if err != nil {
 		return nil, fmt.Errorf("impossibile leggere %s: %w", pomPath, err)
@@ -83,47 +149,165 @@ func parsePomFile(pomPath string) (*pom, error) {
 	if err := xml.Unmarshal(data, &p); err != nil {
 		return nil, fmt.Errorf("errore parsing XML di %s: %w", pomPath, err)
 	}
+	visited[filepath.Clean(pomPath)] = true
+
+	// Usa groupId e version del parent se non specificati
+	if p.Parent != nil {
+		if p.GroupId == "" {
+			p.GroupId = p.Parent.GroupId
+		}
+		if p.Version == "" {
+			p.Version = p.Parent.Version
+		}
+	}
 
-	// Usa il groupId del parent se non specificato
-	if p.GroupId == "" && p.Parent != nil {
-		p.GroupId = p.Parent.GroupId
+	// Eredita proprietà e dipendenze dalla catena dei parent disponibili localmente
+	var inherited map[string]string
+	if p.Parent != nil {
+		if parentPom, parentPath := resolveParent(pomPath, p.Parent, locate, visited); parentPom != nil {
+			inherited = parentPom.properties
+			p.inheritedDependencies = append(p.inheritedDependencies, parentPom.inheritedDependencies...)
+			p.inheritedDependencies = append(p.inheritedDependencies, ownDependencies(parentPom, parentPath)...)
+		}
 	}
 
+	interpolatePom(&p, pomPath, inherited)
+
 	return &p, nil
 }
 
-// extractDependencies estrae le dipendenze da un pom
-func extractDependencies(p *pom) []string {
-	deps := make([]string, 0)
+// resolveParent carica il pom del parent cercandolo prima tramite <relativePath> e poi,
+// se non trovato o con coordinate diverse, tra i progetti gestiti tramite locate.
+// Restituisce nil se il parent non è disponibile localmente (es. scaricato da un repository).
+func resolveParent(pomPath string, parent *pomParent, locate pomLocator, visited map[string]bool) (*pom, string) {
+	candidates := make([]string, 0, 2)
+	if relativePath := parentPomPath(pomPath, parent); relativePath != "" {
+		candidates = append(candidates, relativePath)
+	}
+	if locate != nil {
+		if located, ok := locate(makeIdentifier(parent.GroupId, parent.ArtifactId)); ok {
+			candidates = append(candidates, located)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if visited[filepath.Clean(candidate)] {
+			continue
+		}
+
+		parentPom, err := loadPom(candidate, locate, visited)
+		if err != nil {
+			continue
+		}
+
+		if parentPom.GroupId == parent.GroupId && parentPom.ArtifactId == parent.ArtifactId {
+			return parentPom, candidate
+		}
+	}
+
+	return nil, ""
+}
+
+// extractDependencies estrae le dipendenze effettive di un pom, annotando il file che le dichiara:
+// il parent stesso, le dipendenze ereditate dalla catena dei parent e quelle dichiarate nel pom
+func extractDependencies(p *pom, pomPath string) []buildsystem.Dependency {
+	deps := make([]buildsystem.Dependency, 0)
+
+	// Il parent deve essere installato prima del figlio
+	if p.Parent != nil {
+		deps = append(deps, buildsystem.Dependency{
+			Identifier: makeIdentifier(p.Parent.GroupId, p.Parent.ArtifactId),
+			Source:     pomPath,
+			Kind:       buildsystem.KindParent,
+		})
+	}
+
+	deps = append(deps, p.inheritedDependencies...)
+	deps = append(deps, ownDependencies(p, pomPath)...)
+	return deps
+}
+
+// ownDependencies estrae le dipendenze dichiarate direttamente nel pom: <dependencies>,
+// BOM importati in <dependencyManagement>, plugin di build con le loro dipendenze ed estensioni
+func ownDependencies(p *pom, pomPath string) []buildsystem.Dependency {
+	deps := make([]buildsystem.Dependency, 0)
+	add := func(groupId, artifactId string, kind buildsystem.DependencyKind) {
+		deps = append(deps, buildsystem.Dependency{
+			Identifier: makeIdentifier(groupId, artifactId),
+			Source:     pomPath,
+			Kind:       kind,
+		})
+	}
+
 	if p.Dependencies != nil {
 		for _, dep := range p.Dependencies.Dependency {
-			deps = append(deps, makeIdentifier(dep.GroupId, dep.ArtifactId))
+			add(dep.GroupId, dep.ArtifactId, buildsystem.KindDependency)
+		}
+	}
+
+	// Solo i BOM importati generano una dipendenza: le altre voci di
+	// dependencyManagement fissano versioni senza richiedere l'artifact
+	if p.DependencyManagement != nil && p.DependencyManagement.Dependencies != nil {
+		for _, dep := range p.DependencyManagement.Dependencies.Dependency {
+			if dep.Scope == "import" && dep.Type == "pom" {
+				add(dep.GroupId, dep.ArtifactId, buildsystem.KindImport)
+			}
+		}
+	}
+
+	if p.Build != nil {
+		if p.Build.Plugins != nil {
+			for _, plugin := range p.Build.Plugins.Plugin {
+				add(pluginGroupId(plugin.GroupId), plugin.ArtifactId, buildsystem.KindPlugin)
+				if plugin.Dependencies != nil {
+					for _, dep := range plugin.Dependencies.Dependency {
+						add(dep.GroupId, dep.ArtifactId, buildsystem.KindPlugin)
+					}
+				}
+			}
+		}
+		if p.Build.Extensions != nil {
+			for _, extension := range p.Build.Extensions.Extension {
+				add(extension.GroupId, extension.ArtifactId, buildsystem.KindExtension)
+			}
 		}
 	}
+
 	return deps
 }
 
-// extractModuleDependenciesRecursive estrae le dipendenze da un modulo figlio ricorsivamente
-func extractModuleDependenciesRecursive(modulePomPath string) ([]string, error) {
-	pomData, err := parsePomFile(modulePomPath)
+// pluginGroupId restituisce il groupId di un plugin, applicando il default di Maven se assente
+func pluginGroupId(groupId string) string {
+	if groupId == "" {
+		return defaultPluginGroupId
+	}
+	return groupId
+}
+
+// extractModuleDependenciesRecursive estrae le dipendenze da un modulo figlio ricorsivamente,
+// insieme agli avvisi emersi durante l'analisi dei pom
+func extractModuleDependenciesRecursive(modulePomPath string, locate pomLocator) ([]buildsystem.Dependency, []string, error) {
+	pomData, err := loadPom(modulePomPath, locate, make(map[string]bool))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	deps := extractDependencies(pomData)
+	deps := extractDependencies(pomData, modulePomPath)
+	warnings := pomData.warnings
 
 	// Gestione ricorsiva dei moduli annidati
 	if pomData.Modules != nil && len(pomData.Modules.Module) > 0 {
 		for _, module := range pomData.Modules.Module {
 			nestedPomPath := filepath.Join(filepath.Dir(modulePomPath), module, "pom.xml")
-			nestedDeps, err := extractModuleDependenciesRecursive(nestedPomPath)
+			nestedDeps, nestedWarnings, err := extractModuleDependenciesRecursive(nestedPomPath, locate)
 			if err == nil {
 				deps = append(deps, nestedDeps...)
+				warnings = append(warnings, nestedWarnings...)
 			}
 		}
 	}
 
-	return deps, nil
+	return deps, warnings, nil
 }
 
 // makeIdentifier crea l'identificatore univoco Maven (groupId:artifactId)
@@ -167,54 +351,30 @@ func RegisterSubModules(projectPath string, parentProjectName string, registry *
 	return nil
 }
 
-// BuildDependencyGraph costruisce il grafo delle dipendenze tra i progetti Maven selezionati
-// Questa funzione sostituisce l'implementazione in compat.go eliminando il doppio parsing
-func BuildDependencyGraph(projectNames []string, rootPath string) (map[string][]string, error) {
-	registry := buildsystem.NewArtifactRegistry()
-	projects := make(map[string]*buildsystem.Project)
-
-	// Parse tutti i progetti in una sola passata
-	for _, name := range projectNames {
-		project, err := ParseProject(name, rootPath)
-		if err != nil {
-			return nil, err
-		}
-		projects[name] = project
-
-		// Registra il progetto principale
-		registry.Register(project.Identifier, name)
+// pomIndex associa le coordinate Maven (groupId:artifactId) dei progetti gestiti
+// e dei loro sub-module al percorso del relativo pom.xml
+type pomIndex map[string]string
 
-		// Registra i sub-modules per gestire dipendenze indirette
-		if err := RegisterSubModules(project.Path, name, registry); err != nil {
-			// Ignora errori di registrazione sub-modules (già gestito con Warning in compat.go)
-			// Questo è accettabile perché alcuni sub-modules potrebbero non essere accessibili
-		}
+// add indicizza il pom.xml indicato e, ricorsivamente, quelli dei suoi sub-module
+func (idx pomIndex) add(pomPath string) {
+	pomData, err := parsePomFile(pomPath)
+	if err != nil {
+		return // Ignora pom non leggibili: l'errore emerge durante l'analisi del progetto
 	}
 
-	// Costruisci il grafo delle dipendenze tra i progetti selezionati
-	dependencyGraph := make(map[string][]string)
-	for name, project := range projects {
-		dependencies := make([]string, 0)
+	if pomData.GroupId != "" && pomData.ArtifactId != "" {
+		idx[makeIdentifier(pomData.GroupId, pomData.ArtifactId)] = pomPath
+	}
 
-		for _, depId := range project.Dependencies {
-			// Controlla se la dipendenza è uno dei progetti selezionati
-			if depProjectName, exists := registry.Lookup(depId); exists {
-				// Evita self-dependency
-				if depProjectName != name {
-					dependencies = append(dependencies, depProjectName)
-				}
-			}
+	if pomData.Modules != nil {
+		for _, module := range pomData.Modules.Module {
+			idx.add(filepath.Join(filepath.Dir(pomPath), module, "pom.xml"))
 		}
-
-		dependencyGraph[name] = dependencies
 	}
-
-	return dependencyGraph, nil
 }
 
-// TopologicalSort ordina i progetti in base alle loro dipendenze
-// Restituisce l'ordine di esecuzione corretto o un errore se ci sono cicli
-func TopologicalSort(graphMap map[string][]string) ([]string, error) {
-	dependencyGraph := graph.DependencyGraph(graphMap)
-	return dependencyGraph.TopologicalSort()
+// locate restituisce il pom.xml associato a una coordinata (implementa pomLocator)
+func (idx pomIndex) locate(identifier string) (string, bool) {
+	pomPath, exists := idx[identifier]
+	return pomPath, exists
 }
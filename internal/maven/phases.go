@@ -0,0 +1,99 @@
+package maven
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+// PhaseRule associa le esecuzioni di un plugin Maven a una fase mostrata durante la build.
+// Plugin e Goal sono pattern glob (es. "*-codegen", "*"); il plugin viene confrontato sia con
+// il nome presente nell'output (es. "maven-compiler-plugin") sia con il nome breve (es. "compiler").
+type PhaseRule struct {
+	Plugin string // Pattern del plugin
+	Goal   string // Pattern del goal (vuoto = qualsiasi goal)
+	Name   string // Nome della fase: esecuzioni consecutive con lo stesso nome restano sulla stessa riga
+	Label  string // Testo mostrato nello spinner (vuoto = Name)
+}
+
+// DefaultPhaseRules sono le fasi riconosciute senza configurazione
+var DefaultPhaseRules = []PhaseRule{
+	{Plugin: "clean", Goal: "clean", Name: "CLEAN"},
+	{Plugin: "resources", Goal: "resources", Name: "RESOURCES"},
+	{Plugin: "compiler", Goal: "compile", Name: "COMPILE"},
+	{Plugin: "resources", Goal: "testResources", Name: "TEST-RESOURCES"},
+	{Plugin: "compiler", Goal: "testCompile", Name: "TEST-COMPILE"},
+	{Plugin: "surefire", Goal: "test", Name: "TEST"},
+	{Plugin: "failsafe", Goal: "integration-test", Name: "INTEGRATION-TEST"},
+	{Plugin: "jar", Goal: "jar", Name: "PACKAGE"},
+	{Plugin: "war", Goal: "war", Name: "PACKAGE"},
+	{Plugin: "spring-boot", Goal: "repackage", Name: "REPACKAGE"},
+	{Plugin: "install", Goal: "install", Name: "INSTALL"},
+	{Plugin: "deploy", Goal: "deploy", Name: "DEPLOY"},
+	{Plugin: "frontend", Name: "FRONTEND"},
+	{Plugin: "jacoco", Name: "COVERAGE"},
+	{Plugin: "openapi-generator", Goal: "generate", Name: "CODEGEN", Label: "OPENAPI-CODEGEN"},
+	{Plugin: "jib", Name: "IMAGE", Label: "CONTAINER-IMAGE"},
+	{Plugin: "quarkus", Goal: "build", Name: "QUARKUS-BUILD"},
+}
+
+// matches indica se la regola si applica al plugin e al goal indicati
+func (r PhaseRule) matches(plugin, goal string) bool {
+	if !globMatch(r.Plugin, plugin) && !globMatch(r.Plugin, shortPluginName(plugin)) {
+		return false
+	}
+	return r.Goal == "" || globMatch(r.Goal, goal)
+}
+
+// label restituisce il testo della fase
+func (r PhaseRule) label() string {
+	if r.Label != "" {
+		return r.Label
+	}
+	return r.Name
+}
+
+// globMatch confronta un valore con un pattern glob; un pattern non valido non corrisponde a nulla
+func globMatch(pattern, value string) bool {
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+// shortPluginName restituisce il nome breve di un plugin Maven
+// (es. "maven-compiler-plugin" -> "compiler", "spring-boot-maven-plugin" -> "spring-boot")
+func shortPluginName(plugin string) string {
+	short := strings.TrimPrefix(plugin, "maven-")
+	short = strings.TrimSuffix(short, "-plugin")
+	short = strings.TrimSuffix(short, "-maven")
+	return short
+}
+
+// identifyPhase identifica la fase Maven dal plugin e goal: prima con le regole configurate,
+// poi con quelle predefinite. I plugin non riconosciuti diventano una fase generica "plugin:goal".
+func identifyPhase(rules []PhaseRule, plugin, goal, module string) executor.Phase {
+	for _, ruleSet := range [][]PhaseRule{rules, DefaultPhaseRules} {
+		for _, rule := range ruleSet {
+			if rule.matches(plugin, goal) {
+				return executor.Phase{
+					Name:        rule.Name,
+					Plugin:      plugin,
+					Goal:        goal,
+					Module:      module,
+					Description: fmt.Sprintf("%s @ %s", rule.label(), module),
+				}
+			}
+		}
+	}
+
+	// Fase non riconosciuta: mostra comunque il plugin in esecuzione
+	name := fmt.Sprintf("%s:%s", shortPluginName(plugin), goal)
+	return executor.Phase{
+		Name:        name,
+		Plugin:      plugin,
+		Goal:        goal,
+		Module:      module,
+		Description: fmt.Sprintf("%s @ %s", name, module),
+	}
+}
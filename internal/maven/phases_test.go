@@ -0,0 +1,27 @@
+package maven
+
+import "testing"
+
+func TestIdentifyPhase(t *testing.T) {
+	rules := []PhaseRule{
+		{Plugin: "frontend", Goal: "npm", Name: "NPM", Label: "NPM BUILD"},
+	}
+
+	tests := []struct {
+		plugin, goal, expected string
+	}{
+		{"maven-compiler-plugin", "compile", "COMPILE @ app"},
+		{"compiler", "testCompile", "TEST-COMPILE @ app"},
+		{"maven-failsafe-plugin", "integration-test", "INTEGRATION-TEST @ app"},
+		{"frontend-maven-plugin", "npm", "NPM BUILD @ app"},                          // Regola configurata
+		{"frontend", "install-node-and-npm", "FRONTEND @ app"},                       // Regola predefinita
+		{"build-helper-maven-plugin", "add-source", "build-helper:add-source @ app"}, // Fase generica
+	}
+
+	for _, tt := range tests {
+		phase := identifyPhase(rules, tt.plugin, tt.goal, "app")
+		if phase.Description != tt.expected {
+			t.Errorf("%s:%s: atteso '%s', ottenuto '%s'", tt.plugin, tt.goal, tt.expected, phase.Description)
+		}
+	}
+}
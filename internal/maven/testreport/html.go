@@ -0,0 +1,99 @@
+package testreport
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+)
+
+// slowestInReport è il numero di test più lenti mostrati nella pagina HTML
+const slowestInReport = 20
+
+// htmlTemplate è la pagina HTML autonoma del report, senza risorse esterne
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"seconds": formatSeconds,
+}).Parse(`<!DOCTYPE html>
+<html lang="it">
+<head>
+<meta charset="utf-8">
+<title>Report dei test - {{.GeneratedAt}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+  h1 { font-size: 1.6em; }
+  table { border-collapse: collapse; margin-bottom: 2em; min-width: 60%; }
+  th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+  th { background: #f0f0f0; }
+  td.num { text-align: right; }
+  .ok { color: #1a7f37; }
+  .ko { color: #cf222e; font-weight: bold; }
+  .skip { color: #9a6700; }
+  pre { background: #f6f8fa; padding: 1em; overflow-x: auto; font-size: 0.85em; }
+  details { margin-bottom: 1em; }
+</style>
+</head>
+<body>
+<h1>Report dei test</h1>
+<p>Generato il {{.GeneratedAt}} &mdash;
+  {{.Totals.Tests}} test, <span class="ok">{{.Totals.Passed}} superati</span>,
+  <span class="ko">{{.Totals.Failed}} falliti</span>, <span class="ko">{{.Totals.Errors}} in errore</span>,
+  <span class="skip">{{.Totals.Skipped}} saltati</span> in {{seconds .Totals.Duration}} s</p>
+
+<h2>Progetti</h2>
+<table>
+  <tr><th>Progetto</th><th>Test</th><th>Superati</th><th>Falliti</th><th>Errori</th><th>Saltati</th><th>Durata (s)</th></tr>
+  {{range .Projects}}{{$t := .Totals}}
+  <tr>
+    <td>{{.Project}}</td><td class="num">{{$t.Tests}}</td><td class="num ok">{{$t.Passed}}</td>
+    <td class="num{{if $t.Failed}} ko{{end}}">{{$t.Failed}}</td><td class="num{{if $t.Errors}} ko{{end}}">{{$t.Errors}}</td>
+    <td class="num skip">{{$t.Skipped}}</td><td class="num">{{seconds $t.Duration}}</td>
+  </tr>
+  {{end}}
+</table>
+
+<h2>Test falliti</h2>
+{{if .Failures}}{{range .Failures}}
+<details open>
+  <summary><span class="ko">{{.Status}}</span> <b>{{.Project}}</b> &mdash; {{.FullName}}{{if .Message}}: {{.Message}}{{end}}</summary>
+  {{if .StackTrace}}<pre>{{.StackTrace}}</pre>{{end}}
+</details>
+{{end}}{{else}}<p class="ok">Nessun test fallito</p>{{end}}
+
+<h2>Test più lenti</h2>
+<table>
+  <tr><th>Progetto</th><th>Test</th><th>Durata (s)</th></tr>
+  {{range .Slowest}}
+  <tr><td>{{.Project}}</td><td>{{.FullName}}</td><td class="num">{{seconds .Duration}}</td></tr>
+  {{end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML scrive il report come pagina HTML autonoma
+func (r *Report) WriteHTML(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return fmt.Errorf("impossibile creare il report HTML: %w", err)
+	}
+	defer file.Close()
+
+	data := struct {
+		GeneratedAt string
+		Totals      Totals
+		Projects    []ProjectResult
+		Failures    []TestCase
+		Slowest     []TestCase
+	}{
+		GeneratedAt: time.Now().Format("02/01/2006 15:04"),
+		Totals:      r.Totals(),
+		Projects:    r.Projects,
+		Failures:    r.Failures(),
+		Slowest:     r.Slowest(slowestInReport),
+	}
+
+	if err := htmlTemplate.Execute(file, data); err != nil {
+		return fmt.Errorf("impossibile generare il report HTML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+)
+
+// filePermissions sono i permessi dei file di report
+const filePermissions = 0644
+
+// junitTestSuites è la radice del file JUnit unificato
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Package   string          `xml:"package,attr,omitempty"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit scrive un unico file JUnit XML con i test di tutti i progetti.
+// Ogni suite ha come nome "progetto/suite", così da restare univoca tra progetti diversi.
+func (r *Report) WriteJUnit(path string) error {
+	totals := r.Totals()
+	root := junitTestSuites{
+		Name:     "projman",
+		Tests:    totals.Tests,
+		Failures: totals.Failed,
+		Errors:   totals.Errors,
+		Skipped:  totals.Skipped,
+		Time:     formatSeconds(totals.Duration),
+	}
+
+	for _, project := range r.Projects {
+		suiteIndex := make(map[string]int)
+		suiteTotals := make(map[string]*Totals)
+		for _, test := range project.Tests {
+			key := test.Source + "|" + test.Suite
+			idx, exists := suiteIndex[key]
+			if !exists {
+				idx = len(root.Suites)
+				suiteIndex[key] = idx
+				suiteTotals[key] = &Totals{}
+				root.Suites = append(root.Suites, junitTestSuite{
+					Name:    fmt.Sprintf("%s/%s", project.Project, test.Suite),
+					Package: project.Project,
+				})
+			}
+			suiteTotals[key].add(test)
+			root.Suites[idx].TestCases = append(root.Suites[idx].TestCases, toJUnitTestCase(test))
+		}
+
+		for key, idx := range suiteIndex {
+			suite := &root.Suites[idx]
+			suite.Tests = suiteTotals[key].Tests
+			suite.Failures = suiteTotals[key].Failed
+			suite.Errors = suiteTotals[key].Errors
+			suite.Skipped = suiteTotals[key].Skipped
+			suite.Time = formatSeconds(suiteTotals[key].Duration)
+		}
+	}
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return fmt.Errorf("impossibile serializzare il report JUnit: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, append(data, '\n'), filePermissions); err != nil {
+		return fmt.Errorf("impossibile salvare il report JUnit: %w", err)
+	}
+	return nil
+}
+
+// toJUnitTestCase converte un test nel formato JUnit
+func toJUnitTestCase(test TestCase) junitTestCase {
+	testCase := junitTestCase{
+		Name:      test.Name,
+		ClassName: test.Class,
+		Time:      formatSeconds(test.Duration),
+	}
+	problem := &junitProblem{Message: test.Message, Type: test.Type, Body: test.StackTrace}
+	switch test.Status {
+	case StatusFailed:
+		testCase.Failure = problem
+	case StatusError:
+		testCase.Error = problem
+	case StatusSkipped:
+		testCase.Skipped = &junitProblem{Message: test.Message}
+	}
+	return testCase
+}
+
+// formatSeconds formatta una durata in secondi come nei report di Surefire
+func formatSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
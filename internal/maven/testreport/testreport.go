@@ -0,0 +1,292 @@
+// Package testreport legge i report XML di Surefire e Failsafe dei progetti Maven
+// e produce un report aggregato tra più progetti
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Directory in cui Surefire e Failsafe scrivono i report, relative alla target di ogni modulo
+var reportDirs = []string{"surefire-reports", "failsafe-reports"}
+
+// Status rappresenta l'esito di un singolo test
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"  // Asserzione non verificata
+	StatusError   Status = "error"   // Eccezione inattesa
+	StatusSkipped Status = "skipped" // Test disabilitato o ignorato
+)
+
+// TestCase rappresenta l'esito di un test letto da un report XML
+type TestCase struct {
+	Project    string
+	Suite      string // Nome della suite (di solito la classe di test)
+	Class      string
+	Name       string
+	Duration   time.Duration
+	Status     Status
+	Type       string // Tipo dell'eccezione per test falliti o in errore
+	Message    string
+	StackTrace string
+	Source     string // surefire-reports o failsafe-reports
+}
+
+// FullName restituisce il nome completo del test (classe.metodo)
+func (t TestCase) FullName() string {
+	if t.Class == "" {
+		return t.Name
+	}
+	return t.Class + "." + t.Name
+}
+
+// Totals riassume gli esiti di un insieme di test
+type Totals struct {
+	Tests    int
+	Passed   int
+	Failed   int
+	Errors   int
+	Skipped  int
+	Duration time.Duration
+}
+
+// add aggiunge un test ai totali
+func (t *Totals) add(test TestCase) {
+	t.Tests++
+	t.Duration += test.Duration
+	switch test.Status {
+	case StatusPassed:
+		t.Passed++
+	case StatusFailed:
+		t.Failed++
+	case StatusError:
+		t.Errors++
+	case StatusSkipped:
+		t.Skipped++
+	}
+}
+
+// ProjectResult contiene i test eseguiti in un progetto
+type ProjectResult struct {
+	Project string
+	Tests   []TestCase
+}
+
+// Totals restituisce i totali del progetto
+func (p ProjectResult) Totals() Totals {
+	var totals Totals
+	for _, test := range p.Tests {
+		totals.add(test)
+	}
+	return totals
+}
+
+// Report è il report aggregato dei test di più progetti
+type Report struct {
+	Projects []ProjectResult
+}
+
+// Totals restituisce i totali di tutti i progetti
+func (r *Report) Totals() Totals {
+	var totals Totals
+	for _, project := range r.Projects {
+		for _, test := range project.Tests {
+			totals.add(test)
+		}
+	}
+	return totals
+}
+
+// Slowest restituisce gli n test più lenti di tutti i progetti
+func (r *Report) Slowest(n int) []TestCase {
+	tests := r.allTests()
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].Duration > tests[j].Duration
+	})
+	if len(tests) > n {
+		tests = tests[:n]
+	}
+	return tests
+}
+
+// Failures restituisce i test falliti o in errore di tutti i progetti
+func (r *Report) Failures() []TestCase {
+	failures := make([]TestCase, 0)
+	for _, test := range r.allTests() {
+		if test.Status == StatusFailed || test.Status == StatusError {
+			failures = append(failures, test)
+		}
+	}
+	return failures
+}
+
+// Empty indica se il report non contiene test
+func (r *Report) Empty() bool {
+	return len(r.allTests()) == 0
+}
+
+// allTests restituisce tutti i test in ordine di progetto
+func (r *Report) allTests() []TestCase {
+	tests := make([]TestCase, 0)
+	for _, project := range r.Projects {
+		tests = append(tests, project.Tests...)
+	}
+	return tests
+}
+
+// AddProject legge i report di Surefire e Failsafe di tutti i moduli del progetto
+// (es. modulo/target/surefire-reports/TEST-*.xml) e li aggiunge al report.
+// I progetti senza report non vengono aggiunti.
+func (r *Report) AddProject(projectName, projectPath string) error {
+	files, err := findReportFiles(projectPath)
+	if err != nil {
+		return err
+	}
+
+	result := ProjectResult{Project: projectName}
+	for _, file := range files {
+		tests, err := parseReportFile(file)
+		if err != nil {
+			return err
+		}
+		for i := range tests {
+			tests[i].Project = projectName
+		}
+		result.Tests = append(result.Tests, tests...)
+	}
+
+	if len(result.Tests) > 0 {
+		r.Projects = append(r.Projects, result)
+	}
+	return nil
+}
+
+// findReportFiles cerca i file TEST-*.xml nelle directory dei report di ogni modulo del progetto
+func findReportFiles(projectPath string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		name := entry.Name()
+		if path != projectPath && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "src") {
+			return filepath.SkipDir
+		}
+		if filepath.Base(filepath.Dir(path)) != "target" || !isReportDir(name) {
+			return nil
+		}
+
+		matches, err := filepath.Glob(filepath.Join(path, "TEST-*.xml"))
+		if err != nil {
+			return err
+		}
+		files = append(files, matches...)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("impossibile cercare i report dei test in %s: %w", projectPath, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// isReportDir indica se la directory è una directory di report di Surefire o Failsafe
+func isReportDir(name string) bool {
+	for _, dir := range reportDirs {
+		if name == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// xmlTestSuite rappresenta un file di report di Surefire/Failsafe
+type xmlTestSuite struct {
+	Name      string        `xml:"name,attr"`
+	TestCases []xmlTestCase `xml:"testcase"`
+}
+
+type xmlTestCase struct {
+	Name      string      `xml:"name,attr"`
+	ClassName string      `xml:"classname,attr"`
+	Time      string      `xml:"time,attr"`
+	Failure   *xmlProblem `xml:"failure"`
+	Error     *xmlProblem `xml:"error"`
+	Skipped   *xmlProblem `xml:"skipped"`
+}
+
+type xmlProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// parseReportFile legge un report TEST-*.xml
+func parseReportFile(path string) ([]TestCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("impossibile leggere il report %s: %w", path, err)
+	}
+
+	var suite xmlTestSuite
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("report dei test non valido %s: %w", path, err)
+	}
+
+	source := filepath.Base(filepath.Dir(path))
+	tests := make([]TestCase, 0, len(suite.TestCases))
+	for _, testCase := range suite.TestCases {
+		test := TestCase{
+			Suite:    suite.Name,
+			Class:    testCase.ClassName,
+			Name:     testCase.Name,
+			Duration: parseDuration(testCase.Time),
+			Status:   StatusPassed,
+			Source:   source,
+		}
+
+		switch {
+		case testCase.Failure != nil:
+			test.Status = StatusFailed
+			test.setProblem(testCase.Failure)
+		case testCase.Error != nil:
+			test.Status = StatusError
+			test.setProblem(testCase.Error)
+		case testCase.Skipped != nil:
+			test.Status = StatusSkipped
+			test.Message = testCase.Skipped.Message
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// setProblem copia i dettagli di un fallimento nel test
+func (t *TestCase) setProblem(problem *xmlProblem) {
+	t.Type = problem.Type
+	t.Message = problem.Message
+	t.StackTrace = strings.TrimSpace(problem.Body)
+}
+
+// parseDuration converte la durata in secondi dei report (es. "1.234" o "1,234.5")
+func parseDuration(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
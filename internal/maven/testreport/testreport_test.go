@@ -0,0 +1,68 @@
+package testreport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const surefireReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="com.example.AppTest" time="1.5" tests="3" errors="0" skipped="1" failures="1">
+  <testcase name="shouldWork" classname="com.example.AppTest" time="0.250"/>
+  <testcase name="shouldFail" classname="com.example.AppTest" time="1,200.5">
+    <failure message="expected: &lt;1&gt; but was: &lt;2&gt;" type="org.opentest4j.AssertionFailedError">org.opentest4j.AssertionFailedError: expected: &lt;1&gt; but was: &lt;2&gt;
+	at com.example.AppTest.shouldFail(AppTest.java:20)</failure>
+  </testcase>
+  <testcase name="shouldBeSkipped" classname="com.example.AppTest" time="0"><skipped message="disabled"/></testcase>
+</testsuite>`
+
+func TestReport(t *testing.T) {
+	root := t.TempDir()
+	reportDir := filepath.Join(root, "module-a", "target", "surefire-reports")
+	if err := os.MkdirAll(reportDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "TEST-com.example.AppTest.xml"), []byte(surefireReport), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &Report{}
+	if err := report.AddProject("demo", root); err != nil {
+		t.Fatalf("AddProject ha restituito un errore: %v", err)
+	}
+
+	totals := report.Totals()
+	if totals.Tests != 3 || totals.Passed != 1 || totals.Failed != 1 || totals.Skipped != 1 {
+		t.Errorf("Totali errati: %+v", totals)
+	}
+
+	slowest := report.Slowest(1)
+	if len(slowest) != 1 || slowest[0].Name != "shouldFail" || slowest[0].Duration != 1200500*time.Millisecond {
+		t.Errorf("Test più lento errato: %+v", slowest)
+	}
+
+	failures := report.Failures()
+	if len(failures) != 1 || !strings.Contains(failures[0].StackTrace, "AppTest.java:20") {
+		t.Errorf("Test falliti errati: %+v", failures)
+	}
+
+	junitPath := filepath.Join(root, "merged.xml")
+	if err := report.WriteJUnit(junitPath); err != nil {
+		t.Fatalf("WriteJUnit ha restituito un errore: %v", err)
+	}
+	data, err := os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`<testsuite name="demo/com.example.AppTest"`, `tests="3"`, `<failure message=`, `<skipped message="disabled">`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Il report JUnit non contiene %q:\n%s", expected, data)
+		}
+	}
+
+	if err := report.WriteHTML(filepath.Join(root, "report.html")); err != nil {
+		t.Fatalf("WriteHTML ha restituito un errore: %v", err)
+	}
+}
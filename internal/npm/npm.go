@@ -0,0 +1,273 @@
+// Package npm fornisce funzionalità per l'analisi e la build di pacchetti npm e pnpm
+package npm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+const (
+	// Name è il nome del build system npm
+	Name = "npm"
+	// ProjectFile è il file che identifica un pacchetto npm
+	ProjectFile = "package.json"
+	// pnpmWorkspaceFile dichiara i pacchetti di un workspace pnpm
+	pnpmWorkspaceFile = "pnpm-workspace.yaml"
+	// pnpmLockFile indica che le dipendenze sono gestite con pnpm
+	pnpmLockFile = "pnpm-lock.yaml"
+)
+
+// packageJSON rappresenta i campi di package.json usati per l'analisi
+type packageJSON struct {
+	Name            string            `json:"name"`
+	PackageManager  string            `json:"packageManager"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+	Workspaces      workspaces        `json:"workspaces"`
+}
+
+// workspaces sono i pattern dei pacchetti di un workspace npm: un array di pattern
+// oppure un oggetto con il campo packages
+type workspaces []string
+
+// UnmarshalJSON accetta entrambe le forme del campo workspaces
+func (w *workspaces) UnmarshalJSON(data []byte) error {
+	var patterns []string
+	if err := json.Unmarshal(data, &patterns); err == nil {
+		*w = patterns
+		return nil
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*w = object.Packages
+	return nil
+}
+
+// BuildSystem implementa buildsystem.BuildSystem per i pacchetti npm e pnpm, inclusi i workspace
+type BuildSystem struct{}
+
+// Name implementa buildsystem.BuildSystem
+func (BuildSystem) Name() string {
+	return Name
+}
+
+// Detect implementa buildsystem.BuildSystem: un pacchetto npm contiene un package.json
+func (BuildSystem) Detect(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ProjectFile))
+	return err == nil && !info.IsDir()
+}
+
+// ParseProject implementa buildsystem.BuildSystem: l'identificatore è il nome del pacchetto e le dipendenze
+// sono quelle in dependencies e devDependencies del pacchetto e dei pacchetti del workspace
+func (BuildSystem) ParseProject(projectName, dir string) (*buildsystem.Project, error) {
+	manifestPath := filepath.Join(dir, ProjectFile)
+	manifest, err := readPackage(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("errore analisi progetto %s: %w", projectName, err)
+	}
+
+	project := &buildsystem.Project{
+		Name:         projectName,
+		Path:         dir,
+		Identifier:   manifest.Name,
+		Dependencies: packageDependencies(manifest, manifestPath),
+	}
+	if manifest.Name == "" {
+		project.Identifier = filepath.Base(dir)
+		project.Warnings = append(project.Warnings, fmt.Sprintf("%s: campo name assente, uso il nome della directory", manifestPath))
+	}
+
+	members, warnings := workspaceMembers(dir, manifest)
+	project.Warnings = append(project.Warnings, warnings...)
+	for _, memberPath := range members {
+		member, err := readPackage(memberPath)
+		if err != nil {
+			project.Warnings = append(project.Warnings, err.Error())
+			continue
+		}
+		project.Dependencies = append(project.Dependencies, packageDependencies(member, memberPath)...)
+	}
+
+	return project, nil
+}
+
+// RegisterArtifacts implementa buildsystem.BuildSystem registrando il pacchetto e quelli del workspace
+func (BuildSystem) RegisterArtifacts(project *buildsystem.Project, registry *buildsystem.ArtifactRegistry) {
+	registry.Register(project.Identifier, project.Name)
+
+	manifest, err := readPackage(filepath.Join(project.Path, ProjectFile))
+	if err != nil {
+		return // package.json non leggibile: l'errore è già emerso durante l'analisi del progetto
+	}
+	members, _ := workspaceMembers(project.Path, manifest)
+	for _, memberPath := range members {
+		if member, err := readPackage(memberPath); err == nil && member.Name != "" {
+			registry.Register(member.Name, project.Name)
+		}
+	}
+}
+
+// BuildCommand implementa buildsystem.BuildSystem: esegue lo script indicato (il primo elemento di tasks,
+// seguito da eventuali argomenti) con pnpm se il progetto lo usa, altrimenti con npm.
+// I pacchetti senza lo script non falliscono (--if-present); i test non vengono eseguiti dalla build.
+func (BuildSystem) BuildCommand(dir string, tasks []string, opts buildsystem.BuildOptions) buildsystem.Command {
+	args := []string{"run", "--if-present"}
+	args = append(args, tasks...)
+	args = append(args, opts.Args...)
+	return buildsystem.Command{Executable: packageManager(dir), Args: args}
+}
+
+// OutputParser implementa buildsystem.BuildSystem riconoscendo gli script eseguiti come fasi della build
+func (BuildSystem) OutputParser() executor.OutputParser {
+	return &outputParser{}
+}
+
+// UsesJDK implementa buildsystem.BuildSystem: la build dei pacchetti npm non richiede un JDK
+func (BuildSystem) UsesJDK() bool {
+	return false
+}
+
+// packageManager restituisce il package manager del progetto: pnpm se dichiarato in packageManager
+// o se il progetto ha un lockfile o un workspace pnpm, altrimenti npm
+func packageManager(dir string) string {
+	if manifest, err := readPackage(filepath.Join(dir, ProjectFile)); err == nil &&
+		strings.HasPrefix(manifest.PackageManager, "pnpm@") {
+		return "pnpm"
+	}
+	for _, name := range []string{pnpmLockFile, pnpmWorkspaceFile} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return "pnpm"
+		}
+	}
+	return "npm"
+}
+
+// packageDependencies restituisce le dipendenze e le dipendenze di sviluppo di un pacchetto, in ordine alfabetico
+func packageDependencies(manifest *packageJSON, manifestPath string) []buildsystem.Dependency {
+	names := make([]string, 0, len(manifest.Dependencies)+len(manifest.DevDependencies))
+	for name := range manifest.Dependencies {
+		names = append(names, name)
+	}
+	for name := range manifest.DevDependencies {
+		if _, found := manifest.Dependencies[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	dependencies := make([]buildsystem.Dependency, len(names))
+	for i, name := range names {
+		dependencies[i] = buildsystem.Dependency{
+			Identifier: name,
+			Source:     manifestPath,
+			Kind:       buildsystem.KindDependency,
+		}
+	}
+	return dependencies
+}
+
+// workspaceMembers restituisce i package.json dei pacchetti del workspace, dichiarati nel campo
+// workspaces (npm) o in pnpm-workspace.yaml (pnpm). I pattern che iniziano con '!' escludono pacchetti.
+func workspaceMembers(dir string, manifest *packageJSON) ([]string, []string) {
+	patterns := append([]string{}, manifest.Workspaces...)
+	warnings := make([]string, 0)
+	pnpmPatterns, err := readPnpmWorkspace(filepath.Join(dir, pnpmWorkspaceFile))
+	if err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	patterns = append(patterns, pnpmPatterns...)
+
+	excluded := make(map[string]bool)
+	members := make([]string, 0)
+	for _, pattern := range patterns {
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		// I pattern ricorsivi (packages/**) sono trattati come un livello (packages/*)
+		pattern = strings.ReplaceAll(pattern, "**", "*")
+		matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern), ProjectFile))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: pattern del workspace '%s' non valido", filepath.Join(dir, ProjectFile), pattern))
+			continue
+		}
+		for _, match := range matches {
+			if strings.Contains(filepath.ToSlash(match), "/node_modules/") {
+				continue
+			}
+			if exclude {
+				excluded[match] = true
+			} else if !slices.Contains(members, match) {
+				members = append(members, match)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(members))
+	for _, member := range members {
+		if !excluded[member] {
+			result = append(result, member)
+		}
+	}
+	return result, warnings
+}
+
+// readPackage legge e decodifica un package.json
+func readPackage(manifestPath string) (*packageJSON, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("impossibile leggere %s: %w", manifestPath, err)
+	}
+	var manifest packageJSON
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("errore parsing JSON di %s: %w", manifestPath, err)
+	}
+	return &manifest, nil
+}
+
+// readPnpmWorkspace legge i pattern della lista packages di pnpm-workspace.yaml; un file assente non è un errore.
+// Viene riconosciuta solo la forma a elenco (packages: seguito da righe "- pattern").
+func readPnpmWorkspace(workspacePath string) ([]string, error) {
+	file, err := os.Open(workspacePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("impossibile leggere %s: %w", workspacePath, err)
+	}
+	defer file.Close()
+
+	patterns := make([]string, 0)
+	inPackages := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "-") {
+			inPackages = strings.HasPrefix(trimmed, "packages:")
+			continue
+		}
+		if inPackages && strings.HasPrefix(trimmed, "-") {
+			pattern := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			patterns = append(patterns, strings.Trim(pattern, `'"`))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("impossibile leggere %s: %w", workspacePath, err)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,143 @@
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/buildsystem"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/maven"
+)
+
+// writeFile crea un file con il contenuto indicato, creando le directory mancanti
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestParseProject verifica nome, dipendenze e pacchetti di un workspace npm
+func TestParseProject(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "package.json"), `{
+  "name": "@example/web",
+  "workspaces": ["packages/*", "!packages/legacy"],
+  "dependencies": { "react": "^18.0.0" },
+  "devDependencies": { "@example/orders-client": "1.0.0", "react": "^18.0.0" }
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "ui", "package.json"), `{
+  "name": "@example/ui",
+  "dependencies": { "@example/auth-client": "*" }
+}`)
+	writeFile(t, filepath.Join(dir, "packages", "legacy", "package.json"), `{
+  "name": "@example/legacy",
+  "dependencies": { "jquery": "3.0.0" }
+}`)
+
+	project, err := BuildSystem{}.ParseProject("web", dir)
+	if err != nil {
+		t.Fatalf("ParseProject() errore inatteso: %v", err)
+	}
+	if project.Identifier != "@example/web" {
+		t.Errorf("Identifier = %s, atteso @example/web", project.Identifier)
+	}
+
+	got := make([]string, 0, len(project.Dependencies))
+	for _, dep := range project.Dependencies {
+		got = append(got, dep.Identifier)
+	}
+	if expected := []string{"@example/orders-client", "react", "@example/auth-client"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Dipendenze = %v, attese %v", got, expected)
+	}
+
+	registry := buildsystem.NewArtifactRegistry()
+	BuildSystem{}.RegisterArtifacts(project, registry)
+	if name, found := registry.Lookup("@example/ui"); !found || name != "web" {
+		t.Errorf("il pacchetto @example/ui deve essere registrato come 'web', trovato %q", name)
+	}
+	if _, found := registry.Lookup("@example/legacy"); found {
+		t.Error("il pacchetto escluso dal workspace non deve essere registrato")
+	}
+}
+
+// TestBuildCommand verifica la scelta tra npm e pnpm
+func TestBuildCommand(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "package.json"), `{"name": "web"}`)
+
+	command := BuildSystem{}.BuildCommand(dir, []string{"build"}, buildsystem.BuildOptions{SkipTests: true})
+	if command.Executable != "npm" {
+		t.Errorf("senza lockfile pnpm l'eseguibile deve essere npm, trovato %s", command.Executable)
+	}
+	if expected := []string{"run", "--if-present", "build"}; !reflect.DeepEqual(command.Args, expected) {
+		t.Errorf("args = %v, attesi %v", command.Args, expected)
+	}
+
+	writeFile(t, filepath.Join(dir, "pnpm-lock.yaml"), "lockfileVersion: '9.0'\n")
+	if command := (BuildSystem{}).BuildCommand(dir, []string{"build"}, buildsystem.BuildOptions{}); command.Executable != "pnpm" {
+		t.Errorf("con pnpm-lock.yaml l'eseguibile deve essere pnpm, trovato %s", command.Executable)
+	}
+}
+
+// TestAnalyzeMixedProjects verifica che un pacchetto npm venga ordinato dopo il progetto Maven
+// che pubblica il suo client, dichiarato con provides
+func TestAnalyzeMixedProjects(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "orders", "pom.xml"), `<project>
+  <groupId>com.example</groupId>
+  <artifactId>orders</artifactId>
+</project>`)
+	writeFile(t, filepath.Join(root, "web", "package.json"), `{
+  "name": "web",
+  "dependencies": { "@example/orders-client": "1.0.0" }
+}`)
+
+	analysis, err := buildsystem.Analyze([]string{"web", "orders"}, buildsystem.AnalyzeOptions{
+		ProjectDir: func(projectName string) string {
+			return filepath.Join(root, projectName)
+		},
+		Systems:  []buildsystem.BuildSystem{maven.BuildSystem{}, BuildSystem{}},
+		Provides: map[string][]string{"orders": {"@example/orders-client"}},
+	})
+	if err != nil {
+		t.Fatalf("Analyze() errore inatteso: %v", err)
+	}
+
+	sorted, err := analysis.TopologicalSort(nil)
+	if err != nil {
+		t.Fatalf("TopologicalSort() errore inatteso: %v", err)
+	}
+	if expected := []string{"orders", "web"}; !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("ordine = %v, atteso %v", sorted, expected)
+	}
+}
+
+// TestOutputParser verifica il riconoscimento degli script eseguiti e degli errori TypeScript
+func TestOutputParser(t *testing.T) {
+	parser := BuildSystem{}.OutputParser()
+
+	event := parser.ParseLine("> @example/ui@0.1.0 build /repo/packages/ui")
+	if event.Kind != executor.EventPhaseStarted || event.Phase.Description != "BUILD @ @example/ui" {
+		t.Errorf("intestazione dello script non riconosciuta: %+v", event)
+	}
+	if event := parser.ParseLine("> tsc -p ."); event.Kind != executor.EventNone {
+		t.Errorf("il comando dello script non deve essere una fase: %+v", event)
+	}
+
+	parser.ParseLine("src/app.ts(12,5): error TS2304: Cannot find name 'foo'.")
+	parser.ParseLine("npm ERR! 404 Not Found - GET https://registry.npmjs.org/missing - Not found")
+
+	expected := []executor.Diagnostic{
+		{Kind: executor.DiagnosticCompilation, File: "src/app.ts", Line: 12, Column: 5, Message: "TS2304: Cannot find name 'foo'."},
+		{Kind: executor.DiagnosticDependency, Message: "404 Not Found - GET https://registry.npmjs.org/missing - Not found"},
+	}
+	if diagnostics := parser.Diagnostics(); !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Diagnostiche errate:\natteso  %+v\nottenuto %+v", expected, diagnostics)
+	}
+}
@@ -0,0 +1,78 @@
+package npm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/executor"
+)
+
+// Pattern per il matching dell'output di npm e pnpm
+var (
+	// Intestazione di uno script in esecuzione; pnpm aggiunge la directory del pacchetto
+	// Esempio: > web@1.0.0 build
+	// Esempio: > @example/ui@0.1.0 build /repo/packages/ui
+	// Cattura: nome del pacchetto (senza versione) e script
+	scriptPattern = regexp.MustCompile(`^> ((?:@[^/\s]+/)?[^@\s]+)@\S* (\S+)(?:\s.*)?$`)
+
+	// Errori del compilatore TypeScript, in formato semplice o --pretty
+	// Esempio: src/app.ts(12,5): error TS2304: Cannot find name 'foo'.
+	// Esempio: src/app.ts:12:5 - error TS2304: Cannot find name 'foo'.
+	tscErrorPattern       = regexp.MustCompile(`^(\S+)\((\d+),(\d+)\): error (TS\d+: .+)$`)
+	tscPrettyErrorPattern = regexp.MustCompile(`^(\S+):(\d+):(\d+) - error (TS\d+: .+)$`)
+
+	// Errori di risoluzione delle dipendenze
+	// Esempio: npm ERR! 404 Not Found - GET https://registry.npmjs.org/@example%2fmissing - Not found
+	// Esempio: npm error code ERESOLVE
+	// Esempio: ERR_PNPM_FETCH_404  GET https://registry.npmjs.org/@example%2fmissing: Not Found - 404
+	dependencyErrorPattern = regexp.MustCompile(`^(?:npm (?:ERR!|error) (404 .+|notarget .+|code (?:ERESOLVE|ETARGET|E404))|(ERR_PNPM_\S+\s+.+))$`)
+)
+
+// outputParser implementa executor.OutputParser per l'output degli script npm e pnpm
+type outputParser struct {
+	diagnostics executor.DiagnosticList
+}
+
+// ParseLine implementa executor.OutputParser: ogni script eseguito (anche nei pacchetti
+// di un workspace) è una fase della build
+func (p *outputParser) ParseLine(line string) executor.OutputEvent {
+	line = strings.TrimSpace(executor.StripANSI(line))
+	if line == "" {
+		return executor.OutputEvent{}
+	}
+
+	if matches := scriptPattern.FindStringSubmatch(line); matches != nil {
+		name := strings.ToUpper(matches[2])
+		return executor.OutputEvent{
+			Kind: executor.EventPhaseStarted,
+			Phase: executor.Phase{
+				Name:        name,
+				Goal:        matches[2],
+				Module:      matches[1],
+				Description: fmt.Sprintf("%s @ %s", name, matches[1]),
+			},
+		}
+	}
+
+	for _, pattern := range []*regexp.Regexp{tscErrorPattern, tscPrettyErrorPattern} {
+		if matches := pattern.FindStringSubmatch(line); matches != nil {
+			lineNumber, _ := strconv.Atoi(matches[2])
+			column, _ := strconv.Atoi(matches[3])
+			p.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticCompilation, File: matches[1],
+				Line: lineNumber, Column: column, Message: matches[4]})
+			return executor.OutputEvent{}
+		}
+	}
+
+	if matches := dependencyErrorPattern.FindStringSubmatch(line); matches != nil {
+		p.diagnostics.Add(executor.Diagnostic{Kind: executor.DiagnosticDependency, Message: matches[1] + matches[2]})
+	}
+	return executor.OutputEvent{}
+}
+
+// Diagnostics implementa executor.OutputParser
+func (p *outputParser) Diagnostics() []executor.Diagnostic {
+	return p.diagnostics.Items()
+}
@@ -1,25 +1,56 @@
 package project
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 const (
 	// MavenProjectFile è il nome del file che identifica un progetto Maven
 	MavenProjectFile = "pom.xml"
+	// IgnoreFileName è il file nella root con i pattern delle directory da non scansionare
+	IgnoreFileName = ".projmanignore"
+	// DefaultMaxDepth è la profondità massima di ricerca predefinita sotto la root
+	DefaultMaxDepth = 3
+	// RootSeparator separa il nome della root dal percorso relativo nei nomi qualificati (es. "platform:api")
+	RootSeparator = ":"
 )
 
-// Project rappresenta un progetto Maven con nome e percorso
+// projectFiles sono i file che identificano la directory di un progetto: pom.xml per Maven,
+// settings.gradle e build.gradle (anche in Kotlin DSL) per Gradle, package.json per npm e pnpm
+var projectFiles = []string{
+	MavenProjectFile,
+	"settings.gradle",
+	"settings.gradle.kts",
+	"build.gradle",
+	"build.gradle.kts",
+	"package.json",
+}
+
+// skippedDirs sono le directory che non contengono mai progetti da gestire
+var skippedDirs = map[string]bool{
+	"target":       true,
+	"node_modules": true,
+}
+
+// Project rappresenta un progetto (Maven, Gradle o npm) con nome e percorso
 type Project struct {
-	Name string // Nome del progetto (nome della directory)
+	Name string // Nome del progetto (percorso relativo alla root, es. "backend/payments/api")
 	Path string // Percorso assoluto del progetto
+	Root string // Directory root in cui è stato trovato il progetto
 }
 
-// Discover scansiona la directory root e restituisce tutti i progetti Maven trovati.
-// Un progetto Maven viene identificato dalla presenza di un file pom.xml nella directory.
-func Discover(root string) ([]Project, error) {
+// Discover scansiona ricorsivamente la directory root e restituisce tutti i progetti trovati.
+// Un progetto viene identificato dalla presenza di un pom.xml, di uno script Gradle o di un package.json: una volta
+// trovato non si scende nelle sue sottodirectory (i moduli appartengono al progetto).
+// La ricerca si ferma a maxDepth livelli sotto root (DefaultMaxDepth se maxDepth <= 0) e salta
+// le directory nascoste, quelle di build e quelle indicate nel file .projmanignore della root.
+// I progetti sono identificati dal percorso relativo alla root, con separatore '/'.
+func Discover(root string, maxDepth int) ([]Project, error) {
 	// Valida che la directory root esista
 	if _, err := os.Stat(root); err != nil {
 		if os.IsNotExist(err) {
@@ -28,33 +59,112 @@ func Discover(root string) ([]Project, error) {
 		return nil, fmt.Errorf("impossibile accedere alla directory root '%s': %w", root, err)
 	}
 
-	// Legge le entry nella directory root
-	entries, err := os.ReadDir(root)
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+
+	patterns, err := loadIgnorePatterns(root)
 	if err != nil {
-		return nil, fmt.Errorf("impossibile leggere la directory root '%s': %w", root, err)
+		return nil, err
 	}
 
-	// Scansiona ogni entry alla ricerca di progetti Maven
 	projects := make([]Project, 0)
+	if err := scan(root, "", maxDepth, patterns, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// scan cerca i progetti tra le sottodirectory di root/relDir, scendendo al massimo
+// depth livelli. Le entry sono lette in ordine alfabetico, quindi anche i progetti lo sono.
+func scan(root, relDir string, depth int, patterns []string, projects *[]Project) error {
+	dir := filepath.Join(root, filepath.FromSlash(relDir))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("impossibile leggere la directory '%s': %w", dir, err)
+	}
+
 	for _, entry := range entries {
 		// Considera solo le directory
 		if !entry.IsDir() {
 			continue
 		}
 
-		// Verifica se la directory contiene un pom.xml
-		projectPath := filepath.Join(root, entry.Name())
-		if isMavenProject(projectPath) {
-			projects = append(projects, Project{
-				Name: entry.Name(),
+		relPath := path.Join(relDir, entry.Name())
+		if isIgnored(relPath, patterns) {
+			continue
+		}
+
+		// Verifica se la directory contiene un pom.xml, uno script Gradle o un package.json
+		projectPath := filepath.Join(root, filepath.FromSlash(relPath))
+		if isProject(projectPath) {
+			*projects = append(*projects, Project{
+				Name: relPath,
 				Path: projectPath,
+				Root: root,
 			})
+			continue
+		}
+
+		if depth > 1 {
+			if err := scan(root, relPath, depth-1, patterns, projects); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DiscoverAll scansiona più directory root con le regole di Discover e unisce i progetti trovati.
+// Se lo stesso percorso relativo esiste in più root, i progetti corrispondenti vengono qualificati
+// con il nome della root: "<root>:<percorso>" (es. "platform:api" e "products:api"); gli altri
+// mantengono il percorso relativo. Le root devono avere nomi (ultimo elemento del percorso) distinti.
+func DiscoverAll(roots []string, maxDepth int) ([]Project, error) {
+	labels := make(map[string]string, len(roots))
+	for _, root := range roots {
+		label := RootLabel(root)
+		if other, exists := labels[label]; exists {
+			return nil, fmt.Errorf("le directory root '%s' e '%s' hanno lo stesso nome '%s'", other, root, label)
 		}
+		labels[label] = root
 	}
 
+	projects := make([]Project, 0)
+	for _, root := range roots {
+		found, err := Discover(root, maxDepth)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, found...)
+	}
+
+	// Qualifica con il nome della root i percorsi presenti in più root
+	occurrences := make(map[string]int, len(projects))
+	for _, proj := range projects {
+		occurrences[proj.Name]++
+	}
+	for i, proj := range projects {
+		if occurrences[proj.Name] > 1 {
+			projects[i].Name = RootLabel(proj.Root) + RootSeparator + proj.Name
+		}
+	}
 	return projects, nil
 }
 
+// RootLabel restituisce il nome con cui una directory root qualifica i suoi progetti
+func RootLabel(root string) string {
+	return filepath.Base(filepath.Clean(root))
+}
+
+// RelativePath restituisce il percorso del progetto relativo alla sua root, rimuovendo
+// l'eventuale qualificazione con il nome della root
+func RelativePath(projectName string) string {
+	if _, relPath, found := strings.Cut(projectName, RootSeparator); found {
+		return relPath
+	}
+	return projectName
+}
+
 // Names estrae i nomi di tutti i progetti dalla lista fornita
 func Names(projs []Project) []string {
 	names := make([]string, len(projs))
@@ -89,9 +199,63 @@ func Filter(all []Project, selected []string) []Project {
 	return filtered
 }
 
-// isMavenProject verifica se una directory contiene un file pom.xml
-func isMavenProject(projectPath string) bool {
-	pomPath := filepath.Join(projectPath, MavenProjectFile)
-	_, err := os.Stat(pomPath)
-	return err == nil
+// isProject verifica se una directory contiene uno dei file che identificano un progetto
+func isProject(projectPath string) bool {
+	for _, name := range projectFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// loadIgnorePatterns legge i pattern glob dal file .projmanignore nella root, se presente.
+// Le righe vuote e quelle che iniziano con '#' vengono ignorate.
+func loadIgnorePatterns(root string) ([]string, error) {
+	file, err := os.Open(filepath.Join(root, IgnoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("impossibile leggere %s: %w", IgnoreFileName, err)
+	}
+	defer file.Close()
+
+	patterns := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern := strings.Trim(filepath.ToSlash(line), "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("pattern '%s' non valido in %s: %w", line, IgnoreFileName, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("impossibile leggere %s: %w", IgnoreFileName, err)
+	}
+	return patterns, nil
+}
+
+// isIgnored verifica se una directory va saltata: directory nascoste, di build o corrispondenti
+// a un pattern di .projmanignore. I pattern senza '/' si applicano al nome della directory a
+// qualsiasi livello, gli altri al percorso relativo alla root.
+func isIgnored(relPath string, patterns []string) bool {
+	name := path.Base(relPath)
+	if strings.HasPrefix(name, ".") || skippedDirs[name] {
+		return true
+	}
+	for _, pattern := range patterns {
+		target := relPath
+		if !strings.Contains(pattern, "/") {
+			target = name
+		}
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,94 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	poms := []string{
+		"alpha",
+		"alpha/module",           // modulo di un progetto già trovato
+		"backend/payments/api",   // progetto annidato
+		"backend/core/domain",    // progetto annidato
+		"backend/legacy-billing", // escluso da .projmanignore
+		"deep/a/b/c",             // oltre la profondità massima
+		"tools/target/generated", // dentro target/
+		"web/node_modules/lib",   // dentro node_modules/
+		".cache/project",         // directory nascosta
+		"archive/old/service",    // escluso per nome da .projmanignore
+	}
+	writePoms(t, root, poms...)
+	ignore := "# directory escluse\narchive\nbackend/legacy-*\n"
+	if err := os.WriteFile(filepath.Join(root, IgnoreFileName), []byte(ignore), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := Discover(root, 3)
+	if err != nil {
+		t.Fatalf("Discover() errore inatteso: %v", err)
+	}
+
+	expected := []string{"alpha", "backend/core/domain", "backend/payments/api"}
+	if names := Names(projects); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Discover() = %v, atteso %v", names, expected)
+	}
+	if projects[1].Path != filepath.Join(root, "backend", "core", "domain") {
+		t.Errorf("Path = %s, atteso il percorso assoluto del progetto", projects[1].Path)
+	}
+
+	// Con profondità 1 vengono considerate solo le directory figlie della root
+	projects, err = Discover(root, 1)
+	if err != nil {
+		t.Fatalf("Discover() errore inatteso: %v", err)
+	}
+	if names := Names(projects); !reflect.DeepEqual(names, []string{"alpha"}) {
+		t.Errorf("Discover(depth=1) = %v, atteso [alpha]", names)
+	}
+}
+
+func TestDiscoverAll(t *testing.T) {
+	base := t.TempDir()
+	platform := filepath.Join(base, "platform")
+	products := filepath.Join(base, "products")
+	writePoms(t, platform, "api", "commons")
+	writePoms(t, products, "api", "shop")
+
+	projects, err := DiscoverAll([]string{platform, products}, 0)
+	if err != nil {
+		t.Fatalf("DiscoverAll() errore inatteso: %v", err)
+	}
+
+	// I percorsi presenti in entrambe le root vengono qualificati con il nome della root
+	expected := []string{"platform:api", "commons", "products:api", "shop"}
+	if names := Names(projects); !reflect.DeepEqual(names, expected) {
+		t.Errorf("DiscoverAll() = %v, atteso %v", names, expected)
+	}
+	if projects[2].Root != products || projects[2].Path != filepath.Join(products, "api") {
+		t.Errorf("products:api: root %s e path %s non corretti", projects[2].Root, projects[2].Path)
+	}
+	if rel := RelativePath("products:api"); rel != "api" {
+		t.Errorf("RelativePath() = %s, atteso api", rel)
+	}
+
+	// Root con lo stesso nome non permettono di distinguere i progetti
+	if _, err := DiscoverAll([]string{platform, filepath.Join(products, "platform")}, 0); err == nil {
+		t.Error("DiscoverAll() con root omonime: atteso un errore")
+	}
+}
+
+// writePoms crea un pom.xml minimale in ognuna delle directory indicate sotto root
+func writePoms(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, MavenProjectFile), []byte("<project/>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package runstate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogsDirName è la directory che contiene i log delle esecuzioni, una sottodirectory per esecuzione
+const LogsDirName = "logs"
+
+// logExtension è l'estensione dei file di log dei progetti
+const logExtension = ".log"
+
+// logTimeFormat è il formato dell'orario di avvio nel nome dei file di log: completo di data e millisecondi,
+// così che l'ordine alfabetico dei file sia quello cronologico anche per le esecuzioni riprese in un altro giorno
+const logTimeFormat = "20060102-150405.000"
+
+// ProjectLog descrive il file di log di un progetto in un'esecuzione
+type ProjectLog struct {
+	Project string    // Nome del progetto
+	Path    string    // Percorso del file di log
+	ModTime time.Time // Ultima modifica del file
+	Size    int64     // Dimensione in byte
+}
+
+// LogDir restituisce la directory dei log dell'esecuzione
+func (r *Run) LogDir() string {
+	return filepath.Join(r.dir, LogsDirName, r.ID)
+}
+
+// LogPath restituisce il percorso di un nuovo file di log per il progetto indicato.
+// Il nome contiene l'orario di avvio, così che un progetto ricompilato in un'esecuzione
+// ripresa non sovrascriva il log del tentativo precedente.
+func (r *Run) LogPath(projectName string) string {
+	fileName := fmt.Sprintf("%s_%s%s", logFileName(projectName), time.Now().Format(logTimeFormat), logExtension)
+	return filepath.Join(r.LogDir(), fileName)
+}
+
+// logNameEncoder codifica i separatori non validi nei nomi di file con la loro forma percentuale.
+// Anche '%' viene codificato, così che la codifica sia reversibile e due progetti distinti
+// non condividano mai lo stesso file di log.
+var logNameEncoder = strings.NewReplacer("%", "%25", "/", "%2F", "\\", "%5C", ":", "%3A")
+
+// logNameDecoder ricava il nome del progetto dal nome del file di log
+var logNameDecoder = strings.NewReplacer("%25", "%", "%2F", "/", "%5C", "\\", "%3A", ":")
+
+// logFileName converte il nome di un progetto in un nome di file valido
+func logFileName(projectName string) string {
+	return logNameEncoder.Replace(projectName)
+}
+
+// ListRuns restituisce gli identificativi delle esecuzioni con log salvati nella directory indicata,
+// dalla più recente alla meno recente
+func ListRuns(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, LogsDirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("impossibile leggere la directory dei log: %w", err)
+	}
+
+	runs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			runs = append(runs, entry.Name())
+		}
+	}
+	// Gli identificativi sono timestamp: l'ordine alfabetico inverso è quello cronologico inverso
+	sort.Sort(sort.Reverse(sort.StringSlice(runs)))
+	return runs, nil
+}
+
+// ListLogs restituisce l'ultimo file di log di ogni progetto dell'esecuzione indicata,
+// in ordine alfabetico di progetto
+func ListLogs(dir, runID string) ([]ProjectLog, error) {
+	runDir := filepath.Join(dir, LogsDirName, runID)
+	entries, err := os.ReadDir(runDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("esecuzione '%s' non trovata", runID)
+		}
+		return nil, fmt.Errorf("impossibile leggere i log dell'esecuzione '%s': %w", runID, err)
+	}
+
+	latest := make(map[string]ProjectLog)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, logExtension) {
+			continue
+		}
+		idx := strings.LastIndex(name, "_")
+		if idx == -1 {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		log := ProjectLog{
+			Project: logNameDecoder.Replace(name[:idx]),
+			Path:    filepath.Join(runDir, name),
+			ModTime: info.ModTime(),
+			Size:    info.Size(),
+		}
+		if previous, exists := latest[log.Project]; !exists || newerLog(log, previous) {
+			latest[log.Project] = log
+		}
+	}
+
+	logs := make([]ProjectLog, 0, len(latest))
+	for _, log := range latest {
+		logs = append(logs, log)
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].Project < logs[j].Project
+	})
+	return logs, nil
+}
+
+// newerLog indica se log è più recente di previous: conta l'ultima modifica del file,
+// a parità quella con l'orario di avvio maggiore nel nome
+func newerLog(log, previous ProjectLog) bool {
+	if !log.ModTime.Equal(previous.ModTime) {
+		return log.ModTime.After(previous.ModTime)
+	}
+	return log.Path > previous.Path
+}
+
+// FindLog restituisce l'ultimo file di log del progetto nell'esecuzione indicata
+func FindLog(dir, runID, projectName string) (ProjectLog, error) {
+	logs, err := ListLogs(dir, runID)
+	if err != nil {
+		return ProjectLog{}, err
+	}
+	for _, log := range logs {
+		if log.Project == projectName {
+			return log, nil
+		}
+	}
+	return ProjectLog{}, fmt.Errorf("nessun log per il progetto '%s' nell'esecuzione %s", projectName, runID)
+}
@@ -0,0 +1,126 @@
+package runstate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeLog crea un file di log con l'ultima modifica indicata
+func writeLog(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("[INFO] BUILD SUCCESS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLogPath verifica il nome dei file di log e la directory dell'esecuzione
+func TestLogPath(t *testing.T) {
+	dir := t.TempDir()
+	run := NewTransient(dir, []string{"platform:backend/api"}, nil, nil)
+
+	path := run.LogPath("platform:backend/api")
+	if filepath.Dir(path) != filepath.Join(dir, LogsDirName, run.ID) {
+		t.Errorf("LogPath() = %s, atteso nella directory dell'esecuzione %s", path, run.ID)
+	}
+	name := filepath.Base(path)
+	if !strings.HasPrefix(name, "platform%3Abackend%2Fapi_") || !strings.HasSuffix(name, logExtension) {
+		t.Errorf("nome del log = %s, atteso platform%%3Abackend%%2Fapi_<timestamp>.log", name)
+	}
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(name, "platform%3Abackend%2Fapi_"), logExtension)
+	if _, err := time.Parse(logTimeFormat, timestamp); err != nil {
+		t.Errorf("timestamp del log %s non valido: %v", timestamp, err)
+	}
+}
+
+// TestListLogs verifica che per ogni progetto venga scelto l'ultimo log, anche per un'esecuzione
+// ripresa dopo la mezzanotte, e che FindLog lo trovi dal nome del progetto
+func TestListLogs(t *testing.T) {
+	dir := t.TempDir()
+	runDir := filepath.Join(dir, LogsDirName, "20250101-235900.000")
+	evening := time.Date(2025, 1, 1, 23, 59, 30, 0, time.Local)
+	morning := evening.Add(10 * time.Minute)
+	writeLog(t, filepath.Join(runDir, "core_20250101-235930.000.log"), evening)
+	writeLog(t, filepath.Join(runDir, "core_20250102-000930.000.log"), morning)
+	writeLog(t, filepath.Join(runDir, "backend%2Fapi_20250101-235931.000.log"), evening)
+	writeLog(t, filepath.Join(runDir, "note.txt"), evening)
+
+	logs, err := ListLogs(dir, "20250101-235900.000")
+	if err != nil {
+		t.Fatalf("ListLogs() errore inatteso: %v", err)
+	}
+	if len(logs) != 2 || logs[0].Project != "backend/api" || logs[1].Project != "core" {
+		t.Fatalf("ListLogs() = %+v, attesi backend/api e core", logs)
+	}
+	if filepath.Base(logs[1].Path) != "core_20250102-000930.000.log" {
+		t.Errorf("ultimo log di core = %s, atteso quello del giorno successivo", logs[1].Path)
+	}
+
+	log, err := FindLog(dir, "20250101-235900.000", "backend/api")
+	if err != nil || filepath.Base(log.Path) != "backend%2Fapi_20250101-235931.000.log" {
+		t.Errorf("FindLog(backend/api) = %s, %v", log.Path, err)
+	}
+	if _, err := FindLog(dir, "20250101-235900.000", "web"); err == nil {
+		t.Error("FindLog() deve fallire per un progetto senza log")
+	}
+	if _, err := ListLogs(dir, "20240101-000000.000"); err == nil {
+		t.Error("ListLogs() deve fallire per un'esecuzione inesistente")
+	}
+}
+
+// TestLogFileNameCollisions verifica che progetti con nomi simili non condividano il file di log
+// e che ListLogs restituisca il nome originale di ogni progetto
+func TestLogFileNameCollisions(t *testing.T) {
+	projects := []string{"a/b_c", "a_b/c", "a_b_c", "root:a_b_c", "root_a/b_c", "a%2Fb_c", "a\\b_c"}
+
+	seen := make(map[string]string, len(projects))
+	for _, projectName := range projects {
+		fileName := logFileName(projectName)
+		if other, exists := seen[fileName]; exists {
+			t.Errorf("logFileName(%q) = logFileName(%q) = %s", projectName, other, fileName)
+		}
+		seen[fileName] = projectName
+		if strings.ContainsAny(fileName, "/\\:") {
+			t.Errorf("logFileName(%q) = %s contiene separatori non validi", projectName, fileName)
+		}
+	}
+
+	dir := t.TempDir()
+	run := NewTransient(dir, projects, nil, nil)
+	for _, projectName := range projects {
+		writeLog(t, run.LogPath(projectName), time.Now())
+	}
+	for _, projectName := range projects {
+		log, err := FindLog(dir, run.ID, projectName)
+		if err != nil || log.Project != projectName {
+			t.Errorf("FindLog(%q) = %+v, %v", projectName, log, err)
+		}
+	}
+}
+
+// TestListRuns verifica l'ordine cronologico inverso delle esecuzioni, anche con identificativi
+// avviati nello stesso secondo
+func TestListRuns(t *testing.T) {
+	dir := t.TempDir()
+	for _, runID := range []string{"20250102-080000.000", "20241231-235959.999", "20250102-080000.500"} {
+		if err := os.MkdirAll(filepath.Join(dir, LogsDirName, runID), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs, err := ListRuns(dir)
+	if err != nil {
+		t.Fatalf("ListRuns() errore inatteso: %v", err)
+	}
+	expected := []string{"20250102-080000.500", "20250102-080000.000", "20241231-235959.999"}
+	if strings.Join(runs, ",") != strings.Join(expected, ",") {
+		t.Errorf("ListRuns() = %v, atteso %v", runs, expected)
+	}
+}
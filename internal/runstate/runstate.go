@@ -0,0 +1,211 @@
+// Package runstate salva il piano e l'esito di ogni esecuzione di mvn install,
+// così che un'esecuzione interrotta possa essere ripresa dal punto di errore
+package runstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+)
+
+// StateFileName è il nome del file che contiene lo stato dell'ultima installazione
+const StateFileName = "last_install.json"
+
+// filePermissions sono i permessi del file di stato
+const filePermissions = 0644
+
+// runIDFormat è il formato dell'identificativo di un'esecuzione: il timestamp di avvio al millisecondo,
+// così che esecuzioni avviate nello stesso secondo (es. mvn run e mvn install) non condividano i log
+// e che l'ordine alfabetico degli identificativi sia quello cronologico
+const runIDFormat = "20060102-150405.000"
+
+// ErrNoRun indica che non esiste un'esecuzione precedente da riprendere
+var ErrNoRun = errors.New("nessuna esecuzione precedente da riprendere")
+
+// Status rappresenta l'esito di un progetto all'interno di un'esecuzione
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Non ancora avviato (o interrotto durante la build)
+	StatusSucceeded Status = "succeeded" // Installato con successo
+	StatusFailed    Status = "failed"    // Installazione fallita
+	StatusSkipped   Status = "skipped"   // Saltato perché una dipendenza è fallita
+)
+
+// Run rappresenta lo stato persistente di un'esecuzione di mvn install
+type Run struct {
+	ID        string              `json:"id"`         // Identificativo dell'esecuzione (timestamp di avvio)
+	StartedAt time.Time           `json:"started_at"` // Avvio della prima esecuzione
+	UpdatedAt time.Time           `json:"updated_at"` // Ultimo aggiornamento dello stato
+	Selection []string            `json:"selection"`  // Progetti selezionati, in ordine alfabetico
+	Graph     map[string][]string `json:"graph"`      // Grafo delle dipendenze usato per il piano
+	Plan      []string            `json:"plan"`       // Ordine di installazione calcolato
+	Projects  map[string]Status   `json:"projects"`   // Esito di ogni progetto del piano
+
+	dir  string // Directory dei dati del profilo
+	path string // File di stato (vuoto = esecuzione non salvata)
+	mu   sync.Mutex
+}
+
+// New crea lo stato di una nuova esecuzione da salvare nella directory indicata.
+// Tutti i progetti del piano partono nello stato pending.
+func New(dir string, selection []string, dependencyGraph graph.DependencyGraph, plan []string) *Run {
+	now := time.Now()
+	run := &Run{
+		ID:        now.Format(runIDFormat),
+		StartedAt: now,
+		UpdatedAt: now,
+		Selection: normalizeSelection(selection),
+		Graph:     normalizeGraph(dependencyGraph),
+		Plan:      append([]string(nil), plan...),
+		Projects:  make(map[string]Status, len(plan)),
+		dir:       dir,
+		path:      filepath.Join(dir, StateFileName),
+	}
+	for _, projectName := range plan {
+		run.Projects[projectName] = StatusPending
+	}
+	return run
+}
+
+// NewTransient crea un'esecuzione che non viene salvata su disco e non può essere ripresa,
+// per i comandi diversi da mvn install. I log dei progetti vengono comunque salvati nella directory indicata.
+func NewTransient(dir string, selection []string, dependencyGraph graph.DependencyGraph, plan []string) *Run {
+	run := New(dir, selection, dependencyGraph, plan)
+	run.path = ""
+	return run
+}
+
+// Load carica lo stato dell'ultima esecuzione salvata nella directory indicata.
+// Restituisce ErrNoRun se non è mai stata salvata un'esecuzione.
+func Load(dir string) (*Run, error) {
+	path := filepath.Join(dir, StateFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoRun
+		}
+		return nil, fmt.Errorf("impossibile leggere lo stato dell'ultima esecuzione: %w", err)
+	}
+
+	run := &Run{dir: dir, path: path}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, fmt.Errorf("stato dell'ultima esecuzione non valido: %w", err)
+	}
+	if run.Projects == nil {
+		run.Projects = make(map[string]Status)
+	}
+	return run, nil
+}
+
+// Save salva lo stato dell'esecuzione su disco
+func (r *Run) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.save()
+}
+
+// save salva lo stato; il chiamante deve possedere il lock
+func (r *Run) save() error {
+	r.UpdatedAt = time.Now()
+	if r.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("impossibile serializzare lo stato dell'esecuzione: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, filePermissions); err != nil {
+		return fmt.Errorf("impossibile salvare lo stato dell'esecuzione: %w", err)
+	}
+	return nil
+}
+
+// SetStatus aggiorna l'esito di un progetto e salva subito lo stato,
+// così che un'interruzione improvvisa non perda i progressi. È sicuro per l'uso concorrente.
+func (r *Run) SetStatus(projectName string, status Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Projects[projectName] = status
+	return r.save()
+}
+
+// Status restituisce l'esito registrato per un progetto
+func (r *Run) Status(projectName string) Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if status, ok := r.Projects[projectName]; ok {
+		return status
+	}
+	return StatusPending
+}
+
+// CheckResumable verifica che l'esecuzione possa essere ripresa con la selezione e il grafo attuali.
+// Restituisce un errore se i progetti selezionati o le loro dipendenze sono cambiati.
+func (r *Run) CheckResumable(selection []string, dependencyGraph graph.DependencyGraph) error {
+	if !slices.Equal(r.Selection, normalizeSelection(selection)) {
+		return fmt.Errorf("la selezione dei progetti è cambiata dall'esecuzione %s", r.ID)
+	}
+	if !reflect.DeepEqual(r.Graph, normalizeGraph(dependencyGraph)) {
+		return fmt.Errorf("le dipendenze tra i progetti sono cambiate dall'esecuzione %s", r.ID)
+	}
+	return nil
+}
+
+// Succeeded restituisce i progetti del piano già installati con successo, nell'ordine del piano
+func (r *Run) Succeeded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	succeeded := make([]string, 0)
+	for _, projectName := range r.Plan {
+		if r.Projects[projectName] == StatusSucceeded {
+			succeeded = append(succeeded, projectName)
+		}
+	}
+	return succeeded
+}
+
+// Completed indica se tutti i progetti del piano sono stati installati con successo
+func (r *Run) Completed() bool {
+	return len(r.Succeeded()) == len(r.Plan)
+}
+
+// Reset riporta nello stato pending tutti i progetti non ancora installati con successo
+func (r *Run) Reset() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for projectName, status := range r.Projects {
+		if status != StatusSucceeded {
+			r.Projects[projectName] = StatusPending
+		}
+	}
+	return r.save()
+}
+
+// normalizeSelection restituisce una copia ordinata della selezione
+func normalizeSelection(selection []string) []string {
+	normalized := append([]string{}, selection...)
+	sort.Strings(normalized)
+	return normalized
+}
+
+// normalizeGraph restituisce una copia del grafo con le dipendenze ordinate,
+// così che due grafi equivalenti risultino uguali indipendentemente dall'ordine degli archi
+func normalizeGraph(dependencyGraph graph.DependencyGraph) map[string][]string {
+	normalized := make(map[string][]string, len(dependencyGraph))
+	for node, deps := range dependencyGraph {
+		sorted := append([]string{}, deps...)
+		sort.Strings(sorted)
+		normalized[node] = sorted
+	}
+	return normalized
+}
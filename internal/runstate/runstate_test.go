@@ -0,0 +1,111 @@
+package runstate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SalvatoreSpagnuolo-BipRED/projman/internal/graph"
+)
+
+// testGraph restituisce il grafo core <- api <- web
+func testGraph() graph.DependencyGraph {
+	dependencyGraph := graph.NewDependencyGraph()
+	dependencyGraph.AddNode("core", nil)
+	dependencyGraph.AddNode("api", []string{"core"})
+	dependencyGraph.AddNode("web", []string{"api", "core"})
+	return dependencyGraph
+}
+
+// TestSetStatusAndLoad verifica che gli esiti salvati vengano ricaricati dall'esecuzione successiva
+func TestSetStatusAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(dir); !errors.Is(err, ErrNoRun) {
+		t.Fatalf("Load() senza esecuzioni = %v, atteso ErrNoRun", err)
+	}
+
+	run := New(dir, []string{"web", "core", "api"}, testGraph(), []string{"core", "api", "web"})
+	if err := run.SetStatus("core", StatusSucceeded); err != nil {
+		t.Fatalf("SetStatus() errore inatteso: %v", err)
+	}
+	if err := run.SetStatus("api", StatusFailed); err != nil {
+		t.Fatalf("SetStatus() errore inatteso: %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() errore inatteso: %v", err)
+	}
+	if loaded.ID != run.ID || !reflect.DeepEqual(loaded.Plan, run.Plan) {
+		t.Errorf("esecuzione ricaricata = %s %v, attesa %s %v", loaded.ID, loaded.Plan, run.ID, run.Plan)
+	}
+	for projectName, expected := range map[string]Status{"core": StatusSucceeded, "api": StatusFailed, "web": StatusPending} {
+		if status := loaded.Status(projectName); status != expected {
+			t.Errorf("Status(%s) = %s, atteso %s", projectName, status, expected)
+		}
+	}
+	if succeeded := loaded.Succeeded(); !reflect.DeepEqual(succeeded, []string{"core"}) {
+		t.Errorf("Succeeded() = %v, atteso [core]", succeeded)
+	}
+	if loaded.Completed() {
+		t.Error("Completed() = true con progetti non installati")
+	}
+}
+
+// TestNewTransient verifica che un'esecuzione transitoria non venga salvata
+func TestNewTransient(t *testing.T) {
+	dir := t.TempDir()
+	run := NewTransient(dir, []string{"core"}, testGraph().Subgraph([]string{"core"}), []string{"core"})
+	if err := run.SetStatus("core", StatusSucceeded); err != nil {
+		t.Fatalf("SetStatus() errore inatteso: %v", err)
+	}
+	if _, err := Load(dir); !errors.Is(err, ErrNoRun) {
+		t.Errorf("Load() dopo un'esecuzione transitoria = %v, atteso ErrNoRun", err)
+	}
+}
+
+// TestCheckResumable verifica che la ripresa sia rifiutata se selezione o dipendenze sono cambiate
+func TestCheckResumable(t *testing.T) {
+	run := New(t.TempDir(), []string{"web", "core", "api"}, testGraph(), []string{"core", "api", "web"})
+
+	// Ordine diverso della selezione e degli archi: stessa esecuzione
+	reordered := graph.NewDependencyGraph()
+	reordered.AddNode("core", nil)
+	reordered.AddNode("api", []string{"core"})
+	reordered.AddNode("web", []string{"core", "api"})
+	if err := run.CheckResumable([]string{"api", "core", "web"}, reordered); err != nil {
+		t.Errorf("CheckResumable() con selezione e grafo equivalenti: %v", err)
+	}
+
+	if err := run.CheckResumable([]string{"api", "core"}, testGraph()); err == nil {
+		t.Error("CheckResumable() deve fallire se la selezione è cambiata")
+	}
+
+	changed := testGraph()
+	changed.AddNode("web", []string{"api"})
+	if err := run.CheckResumable([]string{"api", "core", "web"}, changed); err == nil {
+		t.Error("CheckResumable() deve fallire se le dipendenze sono cambiate")
+	}
+}
+
+// TestReset verifica che la ripresa riparta dai progetti non installati
+func TestReset(t *testing.T) {
+	dir := t.TempDir()
+	run := New(dir, []string{"core", "api", "web"}, testGraph(), []string{"core", "api", "web"})
+	_ = run.SetStatus("core", StatusSucceeded)
+	_ = run.SetStatus("api", StatusFailed)
+	_ = run.SetStatus("web", StatusSkipped)
+
+	if err := run.Reset(); err != nil {
+		t.Fatalf("Reset() errore inatteso: %v", err)
+	}
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() errore inatteso: %v", err)
+	}
+	for projectName, expected := range map[string]Status{"core": StatusSucceeded, "api": StatusPending, "web": StatusPending} {
+		if status := loaded.Status(projectName); status != expected {
+			t.Errorf("Status(%s) dopo Reset() = %s, atteso %s", projectName, status, expected)
+		}
+	}
+}